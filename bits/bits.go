@@ -0,0 +1,122 @@
+package bits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+// Cursor returns a cursor over the bits of the bytes from start onwards, most
+// significant bit first. Bytes are only read from start as bits are needed,
+// one at a time, so the stream buffers a byte's worth of bits at a time.
+func Cursor(start nom.Cursor[byte]) nom.Cursor[bool] {
+	var (
+		bits    [8]bool
+		pending []bool
+	)
+	c := start
+	return nom.NewStreamCursorSize(8, func(buf []bool) (int, error) {
+		if len(pending) == 0 {
+			if c.EOF() {
+				return 0, io.EOF
+			}
+			b := c.Read()
+			c = c.Next()
+			for i := range bits {
+				bits[i] = b&(0x80>>i) != 0
+			}
+			pending = bits[:]
+		}
+		n := copy(buf, pending)
+		pending = pending[n:]
+		return n, nil
+	}).WithPartial(start.Partial())
+}
+
+func Bits[T any](p nom.ParseFn[bool, T]) nom.ParseFn[byte, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], T, error) {
+		end, res, err := p(ctx, Cursor(start))
+		if err != nil {
+			var zero T
+			return start, zero, byteError(start, err)
+		}
+		// Skip the remainder of a partially consumed byte.
+		return start.At(start.Position() + (end.Position()+7)/8), res, nil
+	})
+}
+
+func byteError(start nom.Cursor[byte], err error) error {
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		return err
+	}
+	bit := start.Position()*8 + perr.Position
+	message := fmt.Sprintf("at bit %v", bit)
+	if perr.Message != "" {
+		message = fmt.Sprintf("%v at bit %v", perr.Message, bit)
+	}
+	return &nom.ParseError{
+		Kind:     perr.Kind,
+		Position: bit / 8,
+		Needed:   (perr.Needed + 7) / 8,
+		Parser:   perr.Parser,
+		Expected: perr.Expected,
+		Message:  message,
+		Causes:   []error{err},
+	}
+}
+
+func Take(n int) nom.ParseFn[bool, uint64] {
+	take := fn.Take[bool](n)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[bool]) (nom.Cursor[bool], uint64, error) {
+		if n < 0 || n > 64 {
+			return start, 0, nom.Errorf(start, "bits.Take", "cannot take %v bits into a uint64", n)
+		}
+		end, bs, err := take(ctx, start)
+		if err != nil {
+			return start, 0, nom.EOFError(start, "bits.Take", n)
+		}
+		var result uint64
+		for _, b := range bs {
+			result <<= 1
+			if b {
+				result |= 1
+			}
+		}
+		return end, result, nil
+	})
+}
+
+func Tag(pattern uint64, n int) nom.ParseFn[bool, uint64] {
+	take := Take(n)
+	want := fmt.Sprintf("%0*b", n, pattern)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[bool]) (nom.Cursor[bool], uint64, error) {
+		end, got, err := take(ctx, start)
+		if err != nil {
+			return start, 0, err
+		}
+		if got != pattern {
+			return start, 0, &nom.ParseError{
+				Position: start.Position(),
+				Parser:   "bits.Tag",
+				Message:  fmt.Sprintf("unexpected %0*b", n, got),
+				Expected: []string{want},
+			}
+		}
+		return end, got, nil
+	})
+}
+
+func Bool(ctx context.Context, start nom.Cursor[bool]) (nom.Cursor[bool], bool, error) {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[bool]) (nom.Cursor[bool], bool, error) {
+		if start.EOF() {
+			return start, false, nom.EOFError(start, "bits.Bool", 1)
+		}
+		return start.Next(), start.Read(), nil
+	})(ctx, start)
+}
@@ -0,0 +1,92 @@
+package bits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+)
+
+func validate[T any](t *testing.T, name string, p nom.ParseFn[byte, T], in []byte, wantPosition int, wantResult T, wantError bool) {
+	t.Helper()
+
+	name = fmt.Sprintf(name, in)
+	gotCursor, gotResult, err := p(context.Background(), nom.NewCursor(in))
+	if gotCursor.Position() != wantPosition {
+		t.Errorf("%v cursor = %v, want %v", name, gotCursor.Position(), wantPosition)
+		return
+	}
+	if diff := cmp.Diff(wantResult, gotResult); diff != "" {
+		t.Errorf("%v result unexpected diff (-want +got):\n%v\n", name, diff)
+		return
+	}
+	if gotError := (err != nil); gotError != wantError {
+		if wantError {
+			t.Errorf("%v = '%v', want error", name, gotResult)
+		} else {
+			t.Errorf("%v unexpected error: %v", name, err)
+		}
+		return
+	}
+}
+
+func TestBits(t *testing.T) {
+	in := []byte{0x45, 0x00, 0xff}
+	version := fn.Pair(Take(4), Take(4))
+	validate(t, "Bits(Take(4),Take(4))(%x)", Bits(version), in, 1, nom.Tuple[uint64, uint64]{A: 4, B: 5}, false)
+	validate(t, "Bits(Take(3))(%x)", Bits(Take(3)), in, 1, uint64(2), false)
+	validate(t, "Bits(Take(12))(%x)", Bits(Take(12)), in, 2, uint64(0x450), false)
+	validate(t, "Bits(Take(24))(%x)", Bits(Take(24)), in, 3, uint64(0x4500ff), false)
+	validate(t, "Bits(Take(0))(%x)", Bits(Take(0)), in, 0, uint64(0), false)
+	validate(t, "Bits(Take(25))(%x)", Bits(Take(25)), in, 0, uint64(0), true)
+	validate(t, "Bits(Take(65))(%x)", Bits(Take(65)), make([]byte, 9), 0, uint64(0), true)
+	validate(t, "Bits(Take(-1))(%x)", Bits(Take(-1)), in, 0, uint64(0), true)
+	validate(t, "Bits(Bool)(%x)", Bits(Bool), in, 1, false, false)
+	validate(t, "Bits(Bool)(%x)", Bits(Bool), []byte{0x80}, 1, true, false)
+	validate(t, "Bits(Bool)(%x)", Bits(Bool), nil, 0, false, true)
+	validate(t, "Bits(Tag(0b0100,4))(%x)", Bits(Tag(0b0100, 4)), in, 1, uint64(4), false)
+	validate(t, "Bits(Tag(0b0110,4))(%x)", Bits(Tag(0b0110, 4)), in, 0, uint64(0), true)
+
+	// After the bits parser, parsing resumes at the next byte.
+	p := fn.Pair(Bits(Take(1)), Bits(Take(8)))
+	validate(t, "Pair(Bits(Take(1)),Bits(Take(8)))(%x)", p, in, 2, nom.Tuple[uint64, uint64]{A: 0, B: 0}, false)
+}
+
+func TestBitsError(t *testing.T) {
+	p := Bits(fn.Preceded(Take(10), Tag(0b11, 2)))
+	_, _, err := p(context.Background(), nom.NewCursor([]byte{0x00, 0x00}))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("error = %v, want *nom.ParseError", err)
+	}
+	if perr.Position != 1 {
+		t.Errorf("error position = %v, want 1", perr.Position)
+	}
+	if !strings.Contains(perr.Message, "at bit 10") {
+		t.Errorf("error message = %q, want bit offset", perr.Message)
+	}
+	if got := nom.Expectations(err); !cmp.Equal(got, []string{"11"}) {
+		t.Errorf("expectations = %v, want [11]", got)
+	}
+
+	partial := nom.NewCursor([]byte{0x00}).WithPartial(true)
+	_, _, err = Bits(Take(12))(context.Background(), partial)
+	if needed, ok := nom.IsIncomplete(err); !ok || needed != 1 {
+		t.Errorf("Bits(Take(12)) on partial input = %v, want 1 byte needed", err)
+	}
+}
+
+func TestBitsLazy(t *testing.T) {
+	r, w := io.Pipe()
+	go w.Write([]byte{0xa5})
+	_, got, err := Bits(Take(4))(context.Background(), nom.NewStreamCursor(r.Read))
+	if err != nil || got != 0xa {
+		t.Errorf("Bits(Take(4)) = %v, %v, want 0xa", got, err)
+	}
+}
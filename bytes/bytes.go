@@ -2,8 +2,8 @@ package bytes
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io"
 
 	"github.com/jtdubs/go-nom"
 	"github.com/jtdubs/go-nom/fn"
@@ -12,11 +12,8 @@ import (
 
 func Byte(want byte) nom.ParseFn[byte, byte] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], byte, error) {
-		if start.EOF() {
-			return start, 0, fmt.Errorf("got %v, want EOF", start.Read())
-		}
-		if got := start.Read(); got != want {
-			return start, 0, fmt.Errorf("got %v, want %v", got, want)
+		if start.EOF() || start.Read() != want {
+			return start, 0, nom.Expected(start, "bytes.Byte", nom.Describe(want))
 		}
 		return start.Next(), want, nil
 	})
@@ -27,37 +24,49 @@ func Tag(tag string) nom.ParseFn[byte, string] {
 	for _, b := range []byte(tag) {
 		bytes = append(bytes, Byte(b))
 	}
-	return trace.Trace(fn.Preceded(fn.Seq(bytes...), fn.Success[byte](tag)))
+	p := fn.Preceded(fn.Seq(bytes...), fn.Success[byte](tag))
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], string, error) {
+		end, res, err := p(ctx, start)
+		if _, ok := nom.IsIncomplete(err); ok {
+			return start, "", nom.EOFError(start, "bytes.Tag", len(tag))
+		}
+		if !nom.IsRecoverable(err) {
+			return start, "", err
+		}
+		if err != nil {
+			return start, "", &nom.ParseError{
+				Position: start.Position(),
+				Parser:   "bytes.Tag",
+				Expected: []string{fmt.Sprintf("%q", tag)},
+				Causes:   []error{err},
+			}
+		}
+		return end, res, nil
+	})
 }
 
 func Satisfy(testFn func(byte) bool) nom.ParseFn[byte, byte] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], byte, error) {
-		if start.EOF() {
-			return start, 0, errors.New("EOF")
+		if start.EOF() || !testFn(start.Read()) {
+			return start, 0, nom.Expected(start, "bytes.Satisfy")
 		}
-		got := start.Read()
-		if !testFn(got) {
-			return start, 0, fmt.Errorf("%v does not satisfy test", got)
-		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
 func OneOf(allowlist []byte) nom.ParseFn[byte, byte] {
 	lookup := map[byte]struct{}{}
+	var expected []string
 	for _, b := range allowlist {
 		lookup[b] = struct{}{}
+		expected = append(expected, nom.Describe(b))
 	}
 
 	return trace.Trace(func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], byte, error) {
-		if start.EOF() {
-			return start, 0, errors.New("EOF")
+		if _, ok := lookup[start.Read()]; start.EOF() || !ok {
+			return start, 0, nom.Expected(start, "bytes.OneOf", expected...)
 		}
-		got := start.Read()
-		if _, ok := lookup[got]; !ok {
-			return start, 0, fmt.Errorf("%q not allowed", got)
-		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
@@ -68,27 +77,26 @@ func NoneOf(blocklist []byte) nom.ParseFn[byte, byte] {
 	}
 
 	return trace.Trace(func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], byte, error) {
-		if start.EOF() {
-			return start, 0, errors.New("EOF")
+		if _, ok := lookup[start.Read()]; start.EOF() || ok {
+			return start, 0, nom.Expected(start, "bytes.NoneOf", fmt.Sprintf("none of % x", blocklist))
 		}
-		got := start.Read()
-		if _, ok := lookup[got]; ok {
-			return start, 0, fmt.Errorf("%q not allowed", got)
-		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
 func Any() nom.ParseFn[byte, byte] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], byte, error) {
 		if start.EOF() {
-			return start, 0, errors.New("EOF")
+			return start, 0, nom.Expected(start, "bytes.Any")
 		}
-		got := start.Read()
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
 func Cursor(b []byte) nom.Cursor[byte] {
 	return nom.NewCursor(b)
 }
+
+func Reader(r io.Reader) nom.Cursor[byte] {
+	return nom.NewStreamCursor(r.Read)
+}
@@ -0,0 +1,168 @@
+package bytes
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"unsafe"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+var NativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+func number[T any](parser string, size int, decode func([]byte) T) nom.ParseFn[byte, T] {
+	take := fn.Take[byte](size)
+	return func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], T, error) {
+		end, b, err := take(ctx, start)
+		if err != nil {
+			var zero T
+			return start, zero, nom.EOFError(start, parser, size)
+		}
+		return end, decode(b), nil
+	}
+}
+
+func U8(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint8, error) {
+	return trace.Trace(number("bytes.U8", 1, func(b []byte) uint8 { return b[0] }))(ctx, start)
+}
+
+func I8(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int8, error) {
+	return trace.Trace(number("bytes.I8", 1, func(b []byte) int8 { return int8(b[0]) }))(ctx, start)
+}
+
+func U16(order binary.ByteOrder) nom.ParseFn[byte, uint16] {
+	return trace.Trace(number("bytes.U16", 2, func(b []byte) uint16 { return order.Uint16(b) }))
+}
+
+func U16BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint16, error) {
+	return trace.Trace(U16(binary.BigEndian))(ctx, start)
+}
+
+func U16LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint16, error) {
+	return trace.Trace(U16(binary.LittleEndian))(ctx, start)
+}
+
+func U16NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint16, error) {
+	return trace.Trace(U16(NativeEndian))(ctx, start)
+}
+
+func U32(order binary.ByteOrder) nom.ParseFn[byte, uint32] {
+	return trace.Trace(number("bytes.U32", 4, func(b []byte) uint32 { return order.Uint32(b) }))
+}
+
+func U32BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint32, error) {
+	return trace.Trace(U32(binary.BigEndian))(ctx, start)
+}
+
+func U32LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint32, error) {
+	return trace.Trace(U32(binary.LittleEndian))(ctx, start)
+}
+
+func U32NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint32, error) {
+	return trace.Trace(U32(NativeEndian))(ctx, start)
+}
+
+func U64(order binary.ByteOrder) nom.ParseFn[byte, uint64] {
+	return trace.Trace(number("bytes.U64", 8, func(b []byte) uint64 { return order.Uint64(b) }))
+}
+
+func U64BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+	return trace.Trace(U64(binary.BigEndian))(ctx, start)
+}
+
+func U64LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+	return trace.Trace(U64(binary.LittleEndian))(ctx, start)
+}
+
+func U64NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+	return trace.Trace(U64(NativeEndian))(ctx, start)
+}
+
+func I16(order binary.ByteOrder) nom.ParseFn[byte, int16] {
+	return trace.Trace(number("bytes.I16", 2, func(b []byte) int16 { return int16(order.Uint16(b)) }))
+}
+
+func I16BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int16, error) {
+	return trace.Trace(I16(binary.BigEndian))(ctx, start)
+}
+
+func I16LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int16, error) {
+	return trace.Trace(I16(binary.LittleEndian))(ctx, start)
+}
+
+func I16NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int16, error) {
+	return trace.Trace(I16(NativeEndian))(ctx, start)
+}
+
+func I32(order binary.ByteOrder) nom.ParseFn[byte, int32] {
+	return trace.Trace(number("bytes.I32", 4, func(b []byte) int32 { return int32(order.Uint32(b)) }))
+}
+
+func I32BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int32, error) {
+	return trace.Trace(I32(binary.BigEndian))(ctx, start)
+}
+
+func I32LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int32, error) {
+	return trace.Trace(I32(binary.LittleEndian))(ctx, start)
+}
+
+func I32NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int32, error) {
+	return trace.Trace(I32(NativeEndian))(ctx, start)
+}
+
+func I64(order binary.ByteOrder) nom.ParseFn[byte, int64] {
+	return trace.Trace(number("bytes.I64", 8, func(b []byte) int64 { return int64(order.Uint64(b)) }))
+}
+
+func I64BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(I64(binary.BigEndian))(ctx, start)
+}
+
+func I64LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(I64(binary.LittleEndian))(ctx, start)
+}
+
+func I64NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(I64(NativeEndian))(ctx, start)
+}
+
+func F32(order binary.ByteOrder) nom.ParseFn[byte, float32] {
+	return trace.Trace(number("bytes.F32", 4, func(b []byte) float32 { return math.Float32frombits(order.Uint32(b)) }))
+}
+
+func F32BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float32, error) {
+	return trace.Trace(F32(binary.BigEndian))(ctx, start)
+}
+
+func F32LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float32, error) {
+	return trace.Trace(F32(binary.LittleEndian))(ctx, start)
+}
+
+func F32NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float32, error) {
+	return trace.Trace(F32(NativeEndian))(ctx, start)
+}
+
+func F64(order binary.ByteOrder) nom.ParseFn[byte, float64] {
+	return trace.Trace(number("bytes.F64", 8, func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }))
+}
+
+func F64BE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float64, error) {
+	return trace.Trace(F64(binary.BigEndian))(ctx, start)
+}
+
+func F64LE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float64, error) {
+	return trace.Trace(F64(binary.LittleEndian))(ctx, start)
+}
+
+func F64NE(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], float64, error) {
+	return trace.Trace(F64(NativeEndian))(ctx, start)
+}
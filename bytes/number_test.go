@@ -0,0 +1,71 @@
+package bytes
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom"
+)
+
+func validate[T any](t *testing.T, name string, p nom.ParseFn[byte, T], in []byte, wantPosition int, wantResult T, wantError bool) {
+	t.Helper()
+
+	name = fmt.Sprintf(name, in)
+	inCursor := Cursor(in)
+	gotCursor, gotResult, err := p(context.Background(), inCursor)
+	if gotCursor.Position() != wantPosition {
+		t.Errorf("%v cursor = %v, want %v", name, gotCursor.Position(), wantPosition)
+		return
+	}
+	if diff := cmp.Diff(wantResult, gotResult); diff != "" {
+		t.Errorf("%v result unexpected diff (-want +got):\n%v\n", name, diff)
+		return
+	}
+	if gotError := (err != nil); gotError != wantError {
+		if wantError {
+			t.Errorf("%v = '%v', want error", name, gotResult)
+		} else {
+			t.Errorf("%v unexpected error: %v", name, err)
+		}
+		return
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	in := []byte{0x81, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09}
+	validate(t, "U8(%x)", U8, in, 1, uint8(0x81), false)
+	validate(t, "I8(%x)", I8, in, 1, int8(-127), false)
+	validate(t, "U16BE(%x)", U16BE, in, 2, uint16(0x8102), false)
+	validate(t, "U16LE(%x)", U16LE, in, 2, uint16(0x0281), false)
+	validate(t, "U32BE(%x)", U32BE, in, 4, uint32(0x81020304), false)
+	validate(t, "U32LE(%x)", U32LE, in, 4, uint32(0x04030281), false)
+	validate(t, "U64BE(%x)", U64BE, in, 8, uint64(0x8102030405060708), false)
+	validate(t, "U64LE(%x)", U64LE, in, 8, uint64(0x0807060504030281), false)
+	validate(t, "I16BE(%x)", I16BE, in, 2, int16(-32510), false)
+	validate(t, "I32LE(%x)", I32LE, in, 4, int32(0x04030281), false)
+	validate(t, "I64BE(%x)", I64BE, in, 8, int64(-0x7efdfcfbfaf9f8f8), false)
+	validate(t, "U16NE(%x)", U16NE, in, 2, NativeEndian.Uint16(in), false)
+	validate(t, "U32(%x)", U32(binary.BigEndian), in, 4, uint32(0x81020304), false)
+	validate(t, "U64BE(%x)", U64BE, in[:7], 0, uint64(0), true)
+	validate(t, "U16LE(%x)", U16LE, in[:1], 0, uint16(0), true)
+	validate(t, "U8(%x)", U8, nil, 0, uint8(0), true)
+}
+
+func TestFloats(t *testing.T) {
+	f32 := binary.BigEndian.AppendUint32(nil, math.Float32bits(1.5))
+	f64 := binary.LittleEndian.AppendUint64(nil, math.Float64bits(-2.25))
+	validate(t, "F32BE(%x)", F32BE, f32, 4, float32(1.5), false)
+	validate(t, "F64LE(%x)", F64LE, f64, 8, float64(-2.25), false)
+	validate(t, "F64LE(%x)", F64LE, f64[:4], 0, float64(0), true)
+}
+
+func TestIncomplete(t *testing.T) {
+	_, _, err := U32BE(context.Background(), Cursor([]byte{1}).WithPartial(true))
+	if needed, ok := nom.IsIncomplete(err); !ok || needed != 3 {
+		t.Errorf("U32BE() err = %v, want incomplete needing 3", err)
+	}
+}
@@ -0,0 +1,108 @@
+package bytes
+
+import (
+	"context"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+const MaxVarintLen = 10
+
+// leb128 reads the 7-bit groups of a little-endian base 128 integer,
+// sign-extending the result if signed is set.
+func leb128(parser string, maxLen int, signed bool) nom.ParseFn[byte, uint64] {
+	return func(_ context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+		var (
+			result uint64
+			shift  uint
+		)
+		end := start
+		for i := 0; ; i++ {
+			if i >= maxLen {
+				return start, 0, nom.Errorf(start, parser, "varint longer than %v bytes", maxLen)
+			}
+			if end.EOF() {
+				return start, 0, nom.EOFError(start, parser, i+1)
+			}
+			b := end.Read()
+			end = end.Next()
+			if shift == 63 {
+				// Only bit 63 remains: unsigned values allow 0 or 1, and signed
+				// values allow 0 or a sign-extended 1.
+				if valid := b == 0 || (!signed && b == 1) || (signed && b == 0x7f); !valid {
+					return start, 0, nom.Errorf(start, parser, "varint overflows 64 bits")
+				}
+			}
+			result |= uint64(b&0x7f) << shift
+			shift += 7
+			if b&0x80 == 0 {
+				if signed && shift < 64 && b&0x40 != 0 {
+					result |= ^uint64(0) << shift
+				}
+				return end, result, nil
+			}
+		}
+	}
+}
+
+func UvarintMax(maxLen int) nom.ParseFn[byte, uint64] {
+	return trace.Trace(leb128("bytes.Uvarint", maxLen, false))
+}
+
+func SLEB128Max(maxLen int) nom.ParseFn[byte, int64] {
+	p := leb128("bytes.SLEB128", maxLen, true)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+		end, res, err := p(ctx, start)
+		return end, int64(res), err
+	})
+}
+
+func Uvarint(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+	return trace.Trace(UvarintMax(MaxVarintLen))(ctx, start)
+}
+
+// Varint parses a signed varint as written by encoding/binary.PutVarint: a
+// Uvarint holding the zigzag encoding of the value, so 0, -1, 1, -2 are
+// encoded as 0, 1, 2, 3. It is the same encoding as ZigZag and protobuf sint64.
+func Varint(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+		end, res, err := Uvarint(ctx, start)
+		return end, int64(res>>1) ^ -int64(res&1), err
+	})(ctx, start)
+}
+
+// VarintInt64 parses a protobuf int64: a Uvarint reinterpreted as two's
+// complement, so negative values take ten bytes. Use Varint for the encoding
+// of encoding/binary.Varint and protobuf sint64.
+func VarintInt64(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+		end, res, err := Uvarint(ctx, start)
+		return end, int64(res), err
+	})(ctx, start)
+}
+
+func ZigZag(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+		end, res, err := Uvarint(ctx, start)
+		return end, int64(res>>1) ^ -int64(res&1), err
+	})(ctx, start)
+}
+
+func ZigZag32(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int32, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int32, error) {
+		end, res, err := UvarintMax(5)(ctx, start)
+		if err == nil && res > 0xffffffff {
+			return start, 0, nom.Errorf(start, "bytes.ZigZag32", "varint overflows 32 bits")
+		}
+		return end, int32(res>>1) ^ -int32(res&1), err
+	})(ctx, start)
+}
+
+func ULEB128(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], uint64, error) {
+	return trace.Trace(leb128("bytes.ULEB128", MaxVarintLen, false))(ctx, start)
+}
+
+func SLEB128(ctx context.Context, start nom.Cursor[byte]) (nom.Cursor[byte], int64, error) {
+	return trace.Trace(SLEB128Max(MaxVarintLen))(ctx, start)
+}
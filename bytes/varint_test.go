@@ -0,0 +1,56 @@
+package bytes
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestUvarint(t *testing.T) {
+	for _, want := range []uint64{0, 1, 127, 128, 300, math.MaxUint32, math.MaxUint64} {
+		in := binary.AppendUvarint(nil, want)
+		validate(t, "Uvarint(%x)", Uvarint, in, len(in), want, false)
+		validate(t, "ULEB128(%x)", ULEB128, in, len(in), want, false)
+		validate(t, "Uvarint(%x)", Uvarint, in[:len(in)-1], 0, uint64(0), true)
+	}
+	validate(t, "Uvarint(%x)", Uvarint, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}, 0, uint64(0), true)
+	validate(t, "Uvarint(%x)", Uvarint, []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, 0, uint64(0), true)
+	validate(t, "UvarintMax(%x)", UvarintMax(2), []byte{0x80, 0x80, 0x01}, 0, uint64(0), true)
+	validate(t, "UvarintMax(%x)", UvarintMax(2), []byte{0x80, 0x01}, 2, uint64(128), false)
+}
+
+func TestVarint(t *testing.T) {
+	for _, want := range []int64{0, 1, -1, 63, -64, 1000, -1000, math.MaxInt64, math.MinInt64} {
+		zigzag := binary.AppendVarint(nil, want)
+		validate(t, "Varint(%x)", Varint, zigzag, len(zigzag), want, false)
+		validate(t, "ZigZag(%x)", ZigZag, zigzag, len(zigzag), want, false)
+		plain := binary.AppendUvarint(nil, uint64(want))
+		validate(t, "VarintInt64(%x)", VarintInt64, plain, len(plain), want, false)
+	}
+	minusOne := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}
+	validate(t, "VarintInt64(%x)", VarintInt64, minusOne, len(minusOne), int64(-1), false)
+	validate(t, "Varint(%x)", Varint, []byte{0x01}, 1, int64(-1), false)
+	validate(t, "ZigZag(%x)", ZigZag, []byte{0x01}, 1, int64(-1), false)
+	validate(t, "ZigZag32(%x)", ZigZag32, []byte{0x03}, 1, int32(-2), false)
+	validate(t, "ZigZag32(%x)", ZigZag32, []byte{0xff, 0xff, 0xff, 0xff, 0x1f}, 0, int32(0), true)
+}
+
+func TestSLEB128(t *testing.T) {
+	for _, tc := range []struct {
+		in   []byte
+		want int64
+	}{
+		{[]byte{0x02}, 2},
+		{[]byte{0x7e}, -2},
+		{[]byte{0xff, 0x00}, 127},
+		{[]byte{0x81, 0x7f}, -127},
+		{[]byte{0x80, 0x01}, 128},
+		{[]byte{0x80, 0x7f}, -128},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f}, math.MinInt64},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}, math.MaxInt64},
+	} {
+		validate(t, "SLEB128(%x)", SLEB128, tc.in, len(tc.in), tc.want, false)
+	}
+	validate(t, "SLEB128(%x)", SLEB128, []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, 0, int64(0), true)
+	validate(t, "SLEB128(%x)", SLEB128, []byte{0x80}, 0, int64(0), true)
+}
@@ -2,56 +2,256 @@ package cache
 
 import (
 	"context"
-	"log"
 	"runtime"
 
 	"github.com/jtdubs/go-nom"
 )
 
+type ContextKeyType int
+
+const (
+	MemoKey ContextKeyType = iota
+)
+
+// ruleID identifies the parser returned by one call to CacheN. Its name is
+// only used in errors.
+type ruleID struct {
+	name string
+}
+
+// Results are keyed by the cursor's bound as well as its position, as a
+// confined cursor may see less of the input.
+type cacheKey struct {
+	rule     *ruleID
+	position int
+	limit    int
+}
+
 type cacheValue[C comparable, T any] struct {
 	end   nom.Cursor[C]
 	value T
 	err   error
 }
 
-var caches = make(map[string]any)
+// An entry is either a finished result, or a left recursion marker while its
+// rule is still being evaluated for the first time at that position.
+type entry struct {
+	value any
+	lr    *leftRec
+}
+
+type leftRec struct {
+	seed any
+	rule *ruleID
+	head *head
+	next *leftRec
+}
+
+type head struct {
+	rule     *ruleID
+	involved map[*ruleID]struct{}
+	eval     map[*ruleID]struct{}
+}
+
+// A memo belongs to a single parse, and is not safe for concurrent use.
+type memo struct {
+	entries map[cacheKey]*entry
+	heads   map[int]*head
+	lrStack *leftRec
+}
+
+func WithMemo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, MemoKey, newMemo())
+}
+
+func newMemo() *memo {
+	return &memo{
+		entries: make(map[cacheKey]*entry),
+		heads:   make(map[int]*head),
+	}
+}
+
+func memoOf(ctx context.Context) (context.Context, *memo) {
+	if m, ok := ctx.Value(MemoKey).(*memo); ok {
+		return ctx, m
+	}
+	m := newMemo()
+	return context.WithValue(ctx, MemoKey, m), m
+}
+
+func (m *memo) lookup(key cacheKey) (*entry, *head) {
+	return m.entries[key], m.heads[key.position]
+}
+
+func (m *memo) put(key cacheKey, e *entry) {
+	m.entries[key] = e
+}
+
+func (m *memo) setHead(position int, h *head) {
+	if h == nil {
+		delete(m.heads, position)
+	} else {
+		m.heads[position] = h
+	}
+}
+
+func (m *memo) push(lr *leftRec) {
+	lr.next = m.lrStack
+	m.lrStack = lr
+}
+
+func (m *memo) pop() {
+	m.lrStack = m.lrStack.next
+}
+
+// setupLR marks every rule invoked since lr's rule as involved in its left recursion.
+func (m *memo) setupLR(rule *ruleID, lr *leftRec) {
+	if lr.head == nil {
+		lr.head = &head{
+			rule:     rule,
+			involved: make(map[*ruleID]struct{}),
+			eval:     make(map[*ruleID]struct{}),
+		}
+	}
+	for s := m.lrStack; s != nil && s.head != lr.head; s = s.next {
+		s.head = lr.head
+		lr.head.involved[s.rule] = struct{}{}
+	}
+}
 
 func Cache[C comparable, T any](fn nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	return CacheN(1, fn)
 }
 
+// CacheN memoizes fn within a parse. Each call returns a distinct rule, so a
+// cached parser should be built once rather than on every use.
 func CacheN[C comparable, T any](skip int, fn nom.ParseFn[C, T]) nom.ParseFn[C, T] {
-	// Get caller name
-	pc, file, line, ok := runtime.Caller(skip + 1)
-	parent := runtime.FuncForPC(pc)
-	if !ok && parent == nil {
-		log.Printf("Cache failed: unable to determine function for %v:%v", file, line)
-		return fn
+	id := &ruleID{name: "cache.Cache"}
+	if pc, _, _, ok := runtime.Caller(skip + 1); ok {
+		if parent := runtime.FuncForPC(pc); parent != nil {
+			id.name = parent.Name()
+		}
 	}
 
-	// Create cache
-	name := parent.Name()
-	if _, ok := caches[name]; !ok {
-		caches[name] = map[*C]cacheValue[C, T]{}
+	return func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		// Without a memo, this call gets its own, so left recursion still works.
+		ctx, m := memoOf(ctx)
+
+		r := &rule[C, T]{
+			memo:  m,
+			id:    id,
+			key:   keyOf(id, start),
+			start: start,
+			fn:    fn,
+			ctx:   ctx,
+		}
+		res := r.apply()
+		return res.end, res.value, res.err
 	}
-	anyCache, ok := caches[name]
+}
+
+func keyOf[C comparable](id *ruleID, start nom.Cursor[C]) cacheKey {
+	limit, ok := start.Bound()
 	if !ok {
-		log.Printf("Cache failed: unable to create cache for %q", name)
-		return fn
+		limit = -1
 	}
-	cache, ok := anyCache.(map[*C]cacheValue[C, T])
-	if !ok {
-		log.Printf("Cache failed: incompatible cache found for %q", name)
-		return fn
+	return cacheKey{id, start.Position(), limit}
+}
+
+// rule is a single application of a cached ParseFn at a position, following
+// Warth et al., "Packrat Parsers Can Support Left Recursion".
+type rule[C comparable, T any] struct {
+	memo  *memo
+	id    *ruleID
+	key   cacheKey
+	start nom.Cursor[C]
+	fn    nom.ParseFn[C, T]
+	ctx   context.Context
+}
+
+func (r *rule[C, T]) eval() cacheValue[C, T] {
+	end, res, err := r.fn(r.ctx, r.start)
+	return cacheValue[C, T]{end, res, err}
+}
+
+func (r *rule[C, T]) fail() cacheValue[C, T] {
+	var zero T
+	return cacheValue[C, T]{r.start, zero, nom.Errorf(r.start, r.id.name, "left recursion")}
+}
+
+// value recovers a result stored by this rule, whose type is therefore known.
+func (r *rule[C, T]) value(v any) cacheValue[C, T] {
+	return v.(cacheValue[C, T])
+}
+
+func (r *rule[C, T]) apply() cacheValue[C, T] {
+	e := r.recall()
+	if e == nil {
+		lr := &leftRec{seed: r.fail(), rule: r.id}
+		r.memo.push(lr)
+		e = &entry{lr: lr}
+		r.memo.put(r.key, e)
+		res := r.eval()
+		r.memo.pop()
+		if lr.head != nil {
+			lr.seed = res
+			return r.answer(e)
+		}
+		e.value, e.lr = res, nil
+		return res
 	}
+	if e.lr != nil {
+		r.memo.setupLR(r.id, e.lr)
+		return r.value(e.lr.seed)
+	}
+	return r.value(e.value)
+}
 
-	return func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
-		cacheVal, ok := cache[start.Addr()]
-		if !ok {
-			end, res, err := fn(ctx, start)
-			cacheVal = cacheValue[C, T]{end, res, err}
-			cache[start.Addr()] = cacheVal
+func (r *rule[C, T]) recall() *entry {
+	e, h := r.memo.lookup(r.key)
+	if h == nil {
+		return e
+	}
+	_, involved := h.involved[r.id]
+	if e == nil && r.id != h.rule && !involved {
+		return &entry{value: r.fail()}
+	}
+	if _, ok := h.eval[r.id]; ok {
+		delete(h.eval, r.id)
+		if e == nil {
+			e = &entry{}
+			r.memo.put(r.key, e)
+		}
+		e.value, e.lr = r.eval(), nil
+	}
+	return e
+}
+
+func (r *rule[C, T]) answer(e *entry) cacheValue[C, T] {
+	h := e.lr.head
+	if h.rule != r.id {
+		return r.value(e.lr.seed)
+	}
+	e.value, e.lr = e.lr.seed, nil
+	if res := r.value(e.value); res.err != nil {
+		return res
+	}
+	return r.grow(e, h)
+}
+
+func (r *rule[C, T]) grow(e *entry, h *head) cacheValue[C, T] {
+	r.memo.setHead(r.key.position, h)
+	for {
+		h.eval = make(map[*ruleID]struct{}, len(h.involved))
+		for id := range h.involved {
+			h.eval[id] = struct{}{}
+		}
+		res := r.eval()
+		if res.err != nil || res.end.Position() <= r.value(e.value).end.Position() {
+			break
 		}
-		return cacheVal.end, cacheVal.value, cacheVal.err
+		e.value = res
 	}
+	r.memo.setHead(r.key.position, nil)
+	return r.value(e.value)
 }
@@ -3,9 +3,12 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
 )
 
 func TestCache(t *testing.T) {
@@ -24,10 +27,11 @@ func TestCache(t *testing.T) {
 
 	for _, msg := range []string{"Hello", "World"} {
 		count = 0
+		ctx := WithMemo(context.Background())
 		c := nom.NewCursor([]rune(msg))
 		for !c.EOF() {
 			for i := 0; i < 10; i++ {
-				_, got, err := parseFn(context.Background(), c)
+				_, got, err := parseFn(ctx, c)
 				if err != nil {
 					t.Errorf("parseFn() returned unexpected err: %v", err)
 					return
@@ -44,3 +48,117 @@ func TestCache(t *testing.T) {
 		}
 	}
 }
+
+func TestCacheWithoutMemo(t *testing.T) {
+	var count int
+
+	parseFn := Cache(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+		count = count + 1
+		return start.Next(), start.Read(), nil
+	})
+
+	c := nom.NewCursor([]rune("Hello"))
+	for i := 0; i < 10; i++ {
+		parseFn(context.Background(), c)
+	}
+	if count != 10 {
+		t.Errorf("parseFn() count = %v, want %v", count, 10)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	parseFn := Cache(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+		return start.Next(), start.Read(), nil
+	})
+
+	var wg sync.WaitGroup
+	for _, msg := range []string{"Hello", "World", "Gopher", "Parser"} {
+		wg.Add(1)
+		go func(msg string) {
+			defer wg.Done()
+			ctx := WithMemo(context.Background())
+			for c := nom.NewCursor([]rune(msg)); !c.EOF(); c = c.Next() {
+				for i := 0; i < 10; i++ {
+					if _, got, _ := parseFn(ctx, c); got != c.Read() {
+						t.Errorf("parseFn(%q) = %q, want %q", msg, got, c.Read())
+					}
+				}
+			}
+		}(msg)
+	}
+	wg.Wait()
+}
+
+func testBinary(t nom.Tuple[nom.Tuple[string, rune], string]) string {
+	return fmt.Sprintf("(%v%v%v)", t.A.A, string(t.A.B), t.B)
+}
+
+// testGrammar builds a directly and an indirectly left-recursive rule:
+//
+//	direct   := direct '-' digit | digit
+//	indirect := operand '-' digit | digit
+//	operand  := indirect
+func testGrammar() (direct, indirect nom.ParseFn[rune, string]) {
+	digit := Cache(fn.Map(fn.Satisfy(func(r rune) bool { return r >= '0' && r <= '9' }), func(r rune) string { return string(r) }))
+
+	var d, i, operand fn.Ref[rune, string]
+	d.Set(Cache(fn.Alt(
+		fn.Map(fn.Pair(fn.Pair(d.Parse, fn.Expect('-')), digit), testBinary),
+		digit,
+	)))
+	i.Set(Cache(fn.Alt(
+		fn.Map(fn.Pair(fn.Pair(operand.Parse, fn.Expect('-')), digit), testBinary),
+		digit,
+	)))
+	operand.Set(Cache(i.Parse))
+	return d.Parse, i.Parse
+}
+
+func TestLeftRecursion(t *testing.T) {
+	direct, indirect := testGrammar()
+	for name, p := range map[string]nom.ParseFn[rune, string]{
+		"direct":   direct,
+		"indirect": indirect,
+	} {
+		for in, want := range map[string]string{
+			"1":      "1",
+			"1-2":    "(1-2)",
+			"1-2-3":  "((1-2)-3)",
+			"1-2-3-": "((1-2)-3)",
+		} {
+			ctx := WithMemo(context.Background())
+			end, got, err := p(ctx, nom.NewCursor([]rune(in)))
+			if err != nil {
+				t.Errorf("%v(%q) unexpected error: %v", name, in, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%v(%q) = %q, want %q", name, in, got, want)
+			}
+			if wantEnd := len(want) - strings.Count(want, "(")*2; end.Position() != wantEnd {
+				t.Errorf("%v(%q) cursor = %v, want %v", name, in, end.Position(), wantEnd)
+			}
+		}
+	}
+}
+
+func TestLeftRecursionWithoutMemo(t *testing.T) {
+	direct, _ := testGrammar()
+	if _, got, err := direct(context.Background(), nom.NewCursor([]rune("1-2-3"))); err != nil || got != "((1-2)-3)" {
+		t.Errorf("direct(%q) = %q, %v, want %q", "1-2-3", got, err, "((1-2)-3)")
+	}
+}
+
+func TestCacheRuleIdentity(t *testing.T) {
+	tok := func(s string) nom.ParseFn[rune, []rune] { return Cache(fn.Expects([]rune(s))) }
+	a, b := tok("a"), tok("b")
+
+	ctx := WithMemo(context.Background())
+	in := nom.NewCursor([]rune("b"))
+	if _, _, err := a(ctx, in); err == nil {
+		t.Errorf("tok(%q)(%q) succeeded, want error", "a", "b")
+	}
+	if _, got, err := b(ctx, in); err != nil || string(got) != "b" {
+		t.Errorf("tok(%q)(%q) = %q, %v, want %q", "b", "b", string(got), err, "b")
+	}
+}
@@ -0,0 +1,44 @@
+package nom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+var ErrFuelExhausted = errors.New("nom: fuel exhausted")
+
+// WithFuel limits the parse to a number of steps, as counted by Step. Unlike
+// a deadline, the limit is reached at the same point on every run.
+func WithFuel(ctx context.Context, steps int64) context.Context {
+	fuel := &atomic.Int64{}
+	fuel.Store(steps)
+	return context.WithValue(ctx, fuelKey, fuel)
+}
+
+// Step is called by combinators before each repetition or alternative. It
+// returns a failure if ctx is done or has run out of fuel, or if at has been
+// released.
+func Step[C comparable](ctx context.Context, at Cursor[C], parser string) error {
+	err := ctx.Err()
+	if fuel, ok := ctx.Value(fuelKey).(*atomic.Int64); ok && err == nil && fuel.Add(-1) < 0 {
+		err = ErrFuelExhausted
+	}
+	if err == nil && at.released() {
+		err = ErrReleased
+	}
+	if err == nil {
+		return nil
+	}
+	return &ParseError{
+		Kind:     Failure,
+		Position: at.Position(),
+		Parser:   parser,
+		Message:  err.Error(),
+		Causes:   []error{err},
+	}
+}
+
+func IsCancelled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrFuelExhausted)
+}
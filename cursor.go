@@ -2,17 +2,24 @@ package nom
 
 import (
 	"fmt"
+	"math"
 )
 
 type Cursor[T comparable] struct {
-	buffer []T
-	offset int
+	buffer  []T
+	stream  *stream[T]
+	offset  int
+	lines   *lineIndex
+	partial bool
+	bounded bool
+	limit   int
 }
 
 func NewCursor[T comparable](ts []T) Cursor[T] {
 	return Cursor[T]{
 		buffer: ts,
 		offset: 0,
+		lines:  newLineIndex(LineOptions{}),
 	}
 }
 
@@ -20,19 +27,83 @@ func (c Cursor[T]) String() string {
 	return fmt.Sprintf("Cursor(%v)", c.offset)
 }
 
+// available reports whether the underlying input holds at least n items.
+func (c Cursor[T]) available(n int) bool {
+	if c.bounded && n > c.limit {
+		return false
+	}
+	if c.stream != nil {
+		return c.stream.available(n)
+	}
+	return n <= len(c.buffer)
+}
+
+// length is the total number of items in the underlying input.
+func (c Cursor[T]) length() int {
+	if c.bounded && c.available(c.limit) {
+		return c.limit
+	}
+	if c.stream != nil {
+		return c.stream.fetchAll()
+	}
+	return len(c.buffer)
+}
+
+func (c Cursor[T]) slice(from, to int) []T {
+	if c.stream != nil {
+		return c.stream.slice(from, to)
+	}
+	return c.buffer[from:to]
+}
+
 func (c Cursor[T]) EOF() bool {
-	return c.offset >= len(c.buffer)
+	return c.released() || !c.available(c.offset+1)
 }
 
 func (c Cursor[T]) Rest() []T {
 	if c.EOF() {
 		return nil
 	}
-	return c.buffer[c.offset:]
+	return c.slice(c.offset, c.length())
 }
 
 func (c Cursor[T]) Len() int {
-	return len(c.buffer) - c.offset
+	return c.length() - c.offset
+}
+
+func (c Cursor[T]) WithPartial(partial bool) Cursor[T] {
+	c.partial = partial
+	return c
+}
+
+func (c Cursor[T]) Partial() bool {
+	return c.partial
+}
+
+// Limit confines the cursor to at most the next n items.
+func (c Cursor[T]) Limit(n int) Cursor[T] {
+	if n < 0 {
+		n = 0
+	} else if n > math.MaxInt-c.offset {
+		n = math.MaxInt - c.offset
+	}
+	if limit := c.offset + n; !c.bounded || limit < c.limit {
+		c.bounded, c.limit = true, limit
+	}
+	return c
+}
+
+// Bound returns the position the cursor is confined to, if any.
+func (c Cursor[T]) Bound() (int, bool) {
+	return c.limit, c.bounded
+}
+
+func (c Cursor[T]) Has(n int) bool {
+	// No input holds more than MaxInt items, so larger sums cannot be present.
+	if n > math.MaxInt-c.offset {
+		return false
+	}
+	return c.available(c.offset + n)
 }
 
 func (c Cursor[T]) Position() int {
@@ -40,41 +111,60 @@ func (c Cursor[T]) Position() int {
 }
 
 func (c Cursor[T]) Read() (value T) {
-	if c.offset >= len(c.buffer) {
+	if c.EOF() {
 		return
 	}
-	value = c.buffer[c.offset]
-	return
+	if c.stream != nil {
+		return c.stream.at(c.offset)
+	}
+	return c.buffer[c.offset]
 }
 
 func (c Cursor[T]) Next() Cursor[T] {
 	if c.EOF() {
 		return c
 	}
-	return Cursor[T]{
-		buffer: c.buffer,
-		offset: c.offset + 1,
-	}
+	c.offset++
+	return c
 }
 
 func (c Cursor[T]) ToEOF() Cursor[T] {
-	return Cursor[T]{
-		buffer: c.buffer,
-		offset: len(c.buffer),
+	c.offset = c.length()
+	return c
+}
+
+func (c Cursor[T]) At(offset int) Cursor[T] {
+	if offset < 0 {
+		offset = 0
+	}
+	if !c.available(offset) {
+		offset = c.length()
 	}
+	c.offset = offset
+	return c
 }
 
 func (c Cursor[T]) To(other Cursor[T]) []T {
-	if c.EOF() || &c.buffer[0] != &other.buffer[0] {
+	if c.EOF() || !c.sameInput(other) {
 		return nil
 	}
-	return c.buffer[c.offset:other.offset]
+	return c.slice(c.offset, other.offset)
+}
+
+func (c Cursor[T]) sameInput(other Cursor[T]) bool {
+	if c.stream != nil || other.stream != nil {
+		return c.stream == other.stream
+	}
+	return len(other.buffer) > 0 && &c.buffer[0] == &other.buffer[0]
 }
 
 func (c Cursor[T]) Addr() *T {
 	if c.EOF() {
 		return nil
 	}
+	if c.stream != nil {
+		return c.stream.addr(c.offset)
+	}
 	return &c.buffer[c.offset]
 }
 
@@ -0,0 +1,75 @@
+package nom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocation(t *testing.T) {
+	c := NewCursor([]rune("ab\r\n\tcd\ref\n"))
+	for _, tc := range []struct {
+		opts LineOptions
+		pos  int
+		want Location
+	}{
+		{LineOptions{}, 0, Location{1, 1}},
+		{LineOptions{}, 2, Location{1, 3}},
+		{LineOptions{}, 4, Location{2, 1}},
+		{LineOptions{}, 6, Location{2, 3}},
+		{LineOptions{TabWidth: 4}, 6, Location{2, 6}},
+		{LineOptions{}, 9, Location{2, 6}},
+		{LineOptions{CR: true}, 9, Location{3, 2}},
+		{LineOptions{CR: true}, 11, Location{4, 1}},
+	} {
+		got := c.WithLineOptions(tc.opts)
+		for i := 0; i < tc.pos; i++ {
+			got = got.Next()
+		}
+		if loc := got.Location(); loc != tc.want {
+			t.Errorf("Location(%v) with %+v = %v, want %v", tc.pos, tc.opts, loc, tc.want)
+		}
+	}
+}
+
+func TestSpanLocation(t *testing.T) {
+	start := NewCursor([]byte("hello\nworld"))
+	end := start
+	for i := 0; i < 8; i++ {
+		end = end.Next()
+	}
+	gotStart, gotEnd := Span[byte]{start, end}.Location()
+	if want := (Location{1, 1}); gotStart != want {
+		t.Errorf("Span.Location() start = %v, want %v", gotStart, want)
+	}
+	if want := (Location{2, 3}); gotEnd != want {
+		t.Errorf("Span.Location() end = %v, want %v", gotEnd, want)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	c := NewCursor([]rune("hello world")).Next().Limit(4)
+	if got := string(c.Rest()); got != "ello" {
+		t.Errorf("Limit(4).Rest() = %q, want %q", got, "ello")
+	}
+	if got := c.At(8).Position(); got != 5 {
+		t.Errorf("Limit(4).At(8) = %v, want 5", got)
+	}
+	if c.Has(5) || !c.Has(4) {
+		t.Errorf("Limit(4).Has() allows items beyond the limit")
+	}
+	if got := string(c.Limit(10).Rest()); got != "ello" {
+		t.Errorf("Limit(4).Limit(10).Rest() = %q, want %q", got, "ello")
+	}
+	if got := string(c.Next().Limit(2).Rest()); got != "ll" {
+		t.Errorf("Limit(4).Next().Limit(2).Rest() = %q, want %q", got, "ll")
+	}
+	if bound, ok := c.Bound(); !ok || bound != 5 {
+		t.Errorf("Limit(4).Bound() = %v, %v, want 5, true", bound, ok)
+	}
+	if got := string(c.Next().Limit(math.MaxInt).Rest()); got != "llo" {
+		t.Errorf("Limit(4).Next().Limit(MaxInt).Rest() = %q, want %q", got, "llo")
+	}
+	if bound, _ := NewCursor([]rune("hello")).Next().Limit(math.MaxInt).Bound(); bound != math.MaxInt {
+		t.Errorf("Next().Limit(MaxInt).Bound() = %v, want MaxInt", bound)
+	}
+}
@@ -0,0 +1,43 @@
+package nom
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+const DefaultMaxDepth = 1000
+
+func WithMaxDepth(ctx context.Context, max int) context.Context {
+	return context.WithValue(ctx, maxDepthKey, max)
+}
+
+func Depth(ctx context.Context) int {
+	depth, _ := ctx.Value(depthKey).(*atomic.Int64)
+	if depth == nil {
+		return 0
+	}
+	return int(depth.Load())
+}
+
+// Descend enters one level of a recursive rule, returning a function that
+// leaves it again, or a failure if that would exceed the maximum depth. The
+// depth is a single counter added to ctx on the first call, so that lookups
+// in the returned context do not slow down as rules nest.
+func Descend[C comparable](ctx context.Context, at Cursor[C], parser string) (context.Context, func(), error) {
+	max, ok := ctx.Value(maxDepthKey).(int)
+	if !ok {
+		max = DefaultMaxDepth
+	}
+	depth, _ := ctx.Value(depthKey).(*atomic.Int64)
+	if depth == nil {
+		depth = &atomic.Int64{}
+		ctx = context.WithValue(ctx, depthKey, depth)
+	}
+	if depth.Add(1) > int64(max) {
+		depth.Add(-1)
+		err := Errorf(at, parser, "nesting too deep: exceeded maximum depth of %v", max)
+		err.Kind = Failure
+		return ctx, func() {}, err
+	}
+	return ctx, func() { depth.Add(-1) }, nil
+}
@@ -0,0 +1,205 @@
+package nom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ErrorKind int
+
+const (
+	Recoverable ErrorKind = iota
+	Failure
+	Incomplete
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case Recoverable:
+		return "Recoverable"
+	case Failure:
+		return "Failure"
+	case Incomplete:
+		return "Incomplete"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+}
+
+type ParseError struct {
+	Kind     ErrorKind
+	Position int
+	// End is the position just past the offending input, if it spans more
+	// than the item at Position.
+	End      int
+	Needed   int
+	Parser   string
+	Expected []string
+	Message  string
+	Causes   []error
+}
+
+func Errorf[C comparable](at Cursor[C], parser string, format string, args ...any) *ParseError {
+	if at.released() {
+		return releasedError(at, parser)
+	}
+	return &ParseError{
+		Position: at.Position(),
+		Parser:   parser,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func Expected[C comparable](at Cursor[C], parser string, expected ...string) *ParseError {
+	if at.released() {
+		return releasedError(at, parser)
+	}
+	e := &ParseError{
+		Position: at.Position(),
+		Parser:   parser,
+	}
+	if at.EOF() && at.Partial() {
+		e.Kind = Incomplete
+		e.Needed = 1
+		e.Message = "incomplete input"
+	} else if at.EOF() {
+		e.Message = "unexpected EOF"
+	} else {
+		e.Message = fmt.Sprintf("unexpected %v", Describe(at.Read()))
+	}
+	e.Expect(expected...)
+	return e
+}
+
+func EOFError[C comparable](at Cursor[C], parser string, n int) *ParseError {
+	if at.released() {
+		return releasedError(at, parser)
+	}
+	e := &ParseError{
+		Position: at.Position(),
+		Parser:   parser,
+		Message:  fmt.Sprintf("got %v items, want %v", at.Len(), n),
+	}
+	if at.Partial() {
+		e.Kind = Incomplete
+		e.Needed = n - at.Len()
+		e.Message = "incomplete input"
+	}
+	return e
+}
+
+// releasedError reports an attempt to parse input that has been released,
+// which no alternative can recover from.
+func releasedError[C comparable](at Cursor[C], parser string) *ParseError {
+	return &ParseError{
+		Kind:     Failure,
+		Position: at.Position(),
+		Parser:   parser,
+		Message:  ErrReleased.Error(),
+		Causes:   []error{ErrReleased},
+	}
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.Parser != "" {
+		fmt.Fprintf(&sb, "%v: ", e.Parser)
+	}
+	fmt.Fprintf(&sb, "at %v", e.Position)
+	if e.Message != "" {
+		fmt.Fprintf(&sb, ": %v", e.Message)
+	}
+	if e.Kind == Incomplete && e.Needed > 0 {
+		fmt.Fprintf(&sb, ", need %v more", e.Needed)
+	}
+	switch len(e.Expected) {
+	case 0:
+		if e.Message == "" && len(e.Causes) > 0 {
+			causes := make([]string, len(e.Causes))
+			for i, c := range e.Causes {
+				causes[i] = c.Error()
+			}
+			fmt.Fprintf(&sb, ": %v", strings.Join(causes, "; "))
+		}
+	case 1:
+		fmt.Fprintf(&sb, ", expected %v", e.Expected[0])
+	default:
+		fmt.Fprintf(&sb, ", expected one of %v", strings.Join(e.Expected, ", "))
+	}
+	return sb.String()
+}
+
+func (e *ParseError) Unwrap() []error {
+	return e.Causes
+}
+
+func (e *ParseError) Expect(items ...string) *ParseError {
+	for _, item := range items {
+		found := false
+		for _, existing := range e.Expected {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			e.Expected = append(e.Expected, item)
+		}
+	}
+	return e
+}
+
+func (e *ParseError) Wrap(causes ...error) *ParseError {
+	for _, c := range causes {
+		if c != nil {
+			e.Causes = append(e.Causes, c)
+		}
+	}
+	return e
+}
+
+func (e *ParseError) Merge(errs ...error) *ParseError {
+	for _, err := range errs {
+		var perr *ParseError
+		if errors.As(err, &perr) && perr.Position == e.Position {
+			e.Expect(perr.Expected...)
+		}
+	}
+	return e.Wrap(errs...)
+}
+
+func IsRecoverable(err error) bool {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		return true
+	}
+	return perr.Kind == Recoverable
+}
+
+func IsIncomplete(err error) (needed int, ok bool) {
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Kind != Incomplete {
+		return 0, false
+	}
+	return perr.Needed, true
+}
+
+func Expectations(err error) []string {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		return nil
+	}
+	return perr.Expected
+}
+
+func Describe(v any) string {
+	switch v := v.(type) {
+	case rune:
+		return fmt.Sprintf("%q", v)
+	case byte:
+		return fmt.Sprintf("0x%02x", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -3,11 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/jtdubs/go-nom"
 	"github.com/jtdubs/go-nom/cache"
 	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/report"
 	"github.com/jtdubs/go-nom/runes"
 	"github.com/jtdubs/go-nom/trace"
 	"github.com/jtdubs/go-nom/trace/printtracer"
@@ -54,73 +54,77 @@ func (n *NumExpr) Value() int {
 	return n.N
 }
 
-func CT[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
-	return trace.TraceN(1, cache.CacheN(1, p))
-}
+// Each cached rule is built once, as every call to cache.Cache makes a new rule.
+var (
+	number, expression, parens, sumExpression, productExpression, term nom.ParseFn[rune, Expr]
+	sumOperator, productOperator                                       nom.ParseFn[rune, rune]
+)
 
 func Number(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	atoi := func(s string) Expr {
-		n, _ := strconv.Atoi(s)
-		return &NumExpr{n}
-	}
-
-	return CT(fn.Map(runes.Digit1, atoi))(ctx, start)
+	return trace.Trace(number)(ctx, start)
 }
 
 func Expression(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	return CT(fn.Alt(Parens, SumExpression))(ctx, start)
+	return trace.Trace(expression)(ctx, start)
 }
 
 func Parens(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	return CT(runes.SurroundedBy('(', ')', Expression))(ctx, start)
+	return trace.Trace(parens)(ctx, start)
 }
 
 func SumExpression(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	be := &BinaryExpr{}
-	return CT(
-		fn.Alt(
-			fn.Value(Expr(be),
-				runes.Phrase(
-					fn.Bind(&be.L, ProductExpression),
-					fn.Bind(&be.Op, SumOperator),
-					fn.Bind(&be.R, SumExpression),
-				),
-			),
-			ProductExpression,
-		),
-	)(ctx, start)
+	return trace.Trace(sumExpression)(ctx, start)
 }
 
 func SumOperator(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return CT(runes.OneOf("+-"))(ctx, start)
+	return trace.Trace(sumOperator)(ctx, start)
 }
 
 func ProductExpression(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	be := &BinaryExpr{}
-	return CT(
-		fn.Alt(
-			fn.Value(Expr(be),
-				runes.Phrase(
-					fn.Bind(&be.L, Term),
-					fn.Bind(&be.Op, ProductOperator),
-					fn.Bind(&be.R, ProductExpression),
-				),
-			),
-			Term,
-		),
-	)(ctx, start)
+	return trace.Trace(productExpression)(ctx, start)
 }
 
 func ProductOperator(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return CT(runes.OneOf("*/"))(ctx, start)
+	return trace.Trace(productOperator)(ctx, start)
 }
 
 func Term(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], Expr, error) {
-	return CT(fn.Alt(Number, Parens))(ctx, start)
+	return trace.Trace(term)(ctx, start)
+}
+
+func token[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
+	return runes.Lexeme(p)
+}
+
+func binary(t nom.Tuple[nom.Tuple[Expr, rune], Expr]) Expr {
+	return &BinaryExpr{L: t.A.A, Op: t.A.B, R: t.B}
+}
+
+func num(n uint32) Expr {
+	return &NumExpr{int(n)}
 }
 
 func init() {
 	trace.TraceSupported()
+
+	number = cache.Cache(fn.Map(runes.Uint[uint32], num))
+	expression = cache.Cache(SumExpression)
+	parens = cache.Cache(fn.Recursive(runes.SurroundedBy('(', ')', Expression)))
+	sumExpression = cache.Cache(
+		fn.Alt(
+			fn.Map(fn.Pair(fn.Pair(SumExpression, token(SumOperator)), ProductExpression), binary),
+			ProductExpression,
+		),
+	)
+	sumOperator = cache.Cache(runes.OneOf("+-"))
+	productExpression = cache.Cache(
+		fn.Alt(
+			fn.Map(fn.Pair(fn.Pair(ProductExpression, token(ProductOperator)), Term), binary),
+			Term,
+		),
+	)
+	productOperator = cache.Cache(runes.OneOf("*/"))
+	term = cache.Cache(token(fn.Alt(Number, Parens)))
 }
 
 func main() {
@@ -129,12 +133,12 @@ func main() {
 		opts.IncludePackage("main")
 		return opts.Tracer()
 	}()
-	ctx := trace.WithTracing(trace.WithTracer(context.Background(), tracer))
+	ctx := cache.WithMemo(trace.WithTracing(trace.WithTracer(context.Background(), tracer)))
 
-	start := runes.Cursor("    (1*7 + 1 + (2*3+	4/2))  ")
+	start := runes.Cursor("    (1*7 + 1 + (2*3+	4/2)) - 8 - 2 ")
 	rest, result, err := Expression(ctx, start)
 	if err != nil {
-		fmt.Printf("Error: %v", err)
+		fmt.Print(report.String("input", start, err))
 		return
 	}
 	fmt.Printf("Expression %v = %v\n", result, result.Value())
@@ -0,0 +1,200 @@
+package expr
+
+import (
+	"context"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+type Assoc int
+
+const (
+	Left Assoc = iota
+	Right
+	NonAssoc
+)
+
+type prefixOp[C comparable, O, T any] struct {
+	power int
+	op    nom.ParseFn[C, O]
+	build func(O, T) T
+}
+
+type infixOp[C comparable, O, T any] struct {
+	assoc Assoc
+	power int
+	op    nom.ParseFn[C, O]
+	build func(O, T, T) T
+}
+
+type postfixOp[C comparable, O, T any] struct {
+	power int
+	op    nom.ParseFn[C, O]
+	build func(O, T) T
+}
+
+type ternaryOp[C comparable, O, T any] struct {
+	power         int
+	first, second nom.ParseFn[C, O]
+	build         func(T, T, T) T
+}
+
+// Parser builds an operator-precedence (Pratt) parser. Operators with a higher
+// power bind more tightly, and operators are tried in the order they are added.
+type Parser[C comparable, O, T any] struct {
+	atom    nom.ParseFn[C, T]
+	prefix  []prefixOp[C, O, T]
+	infix   []infixOp[C, O, T]
+	postfix []postfixOp[C, O, T]
+	ternary []ternaryOp[C, O, T]
+}
+
+func New[C comparable, O, T any](atom nom.ParseFn[C, T]) *Parser[C, O, T] {
+	return &Parser[C, O, T]{atom: atom}
+}
+
+func (p *Parser[C, O, T]) Prefix(power int, op nom.ParseFn[C, O], build func(O, T) T) *Parser[C, O, T] {
+	p.prefix = append(p.prefix, prefixOp[C, O, T]{power, op, build})
+	return p
+}
+
+func (p *Parser[C, O, T]) Infix(assoc Assoc, power int, op nom.ParseFn[C, O], build func(O, T, T) T) *Parser[C, O, T] {
+	p.infix = append(p.infix, infixOp[C, O, T]{assoc, power, op, build})
+	return p
+}
+
+func (p *Parser[C, O, T]) Postfix(power int, op nom.ParseFn[C, O], build func(O, T) T) *Parser[C, O, T] {
+	p.postfix = append(p.postfix, postfixOp[C, O, T]{power, op, build})
+	return p
+}
+
+func (p *Parser[C, O, T]) Ternary(power int, first, second nom.ParseFn[C, O], build func(T, T, T) T) *Parser[C, O, T] {
+	p.ternary = append(p.ternary, ternaryOp[C, O, T]{power, first, second, build})
+	return p
+}
+
+func (p *Parser[C, O, T]) ParseFn() nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		end, res, err := p.parse(ctx, start, 0)
+		if err != nil {
+			var zero T
+			return start, zero, err
+		}
+		return end, res, nil
+	})
+}
+
+// Binding powers are doubled so that associativity can be expressed as an
+// off-by-one between the left and right binding power of an operator.
+func bindingPower(assoc Assoc, power int) (left, right int) {
+	if assoc == Right {
+		return 2*power + 1, 2 * power
+	}
+	return 2 * power, 2*power + 1
+}
+
+func (p *Parser[C, O, T]) parse(ctx context.Context, start nom.Cursor[C], minPower int) (nom.Cursor[C], T, error) {
+	end, lhs, err := p.operand(ctx, start)
+	if err != nil {
+		return start, lhs, err
+	}
+
+	nonAssoc := -1
+loop:
+	for {
+		for _, op := range p.postfix {
+			opEnd, o, err := op.op(ctx, end)
+			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, lhs, err
+				}
+				continue
+			}
+			if left, _ := bindingPower(Left, op.power); left < minPower {
+				continue
+			}
+			end, lhs = opEnd, op.build(o, lhs)
+			continue loop
+		}
+
+		for _, op := range p.infix {
+			opEnd, o, err := op.op(ctx, end)
+			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, lhs, err
+				}
+				continue
+			}
+			left, right := bindingPower(op.assoc, op.power)
+			if left < minPower {
+				continue
+			}
+			if op.assoc == NonAssoc && op.power == nonAssoc {
+				return start, lhs, nom.Errorf(end, "expr.Parser", "non-associative operator cannot be chained")
+			}
+			rhsEnd, rhs, err := p.parse(ctx, opEnd, right)
+			if err != nil {
+				return start, lhs, err
+			}
+			end, lhs = rhsEnd, op.build(o, lhs, rhs)
+			nonAssoc = -1
+			if op.assoc == NonAssoc {
+				nonAssoc = op.power
+			}
+			continue loop
+		}
+
+		for _, op := range p.ternary {
+			firstEnd, _, err := op.first(ctx, end)
+			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, lhs, err
+				}
+				continue
+			}
+			left, right := bindingPower(Right, op.power)
+			if left < minPower {
+				continue
+			}
+			middleEnd, middle, err := p.parse(ctx, firstEnd, 0)
+			if err != nil {
+				return start, lhs, err
+			}
+			secondEnd, _, err := op.second(ctx, middleEnd)
+			if err != nil {
+				return start, lhs, err
+			}
+			rhsEnd, rhs, err := p.parse(ctx, secondEnd, right)
+			if err != nil {
+				return start, lhs, err
+			}
+			end, lhs = rhsEnd, op.build(lhs, middle, rhs)
+			nonAssoc = -1
+			continue loop
+		}
+
+		break
+	}
+	return end, lhs, nil
+}
+
+func (p *Parser[C, O, T]) operand(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+	for _, op := range p.prefix {
+		opEnd, o, err := op.op(ctx, start)
+		if err != nil {
+			if !nom.Recover(ctx, err) {
+				var zero T
+				return start, zero, err
+			}
+			continue
+		}
+		_, right := bindingPower(Left, op.power)
+		end, rhs, err := p.parse(ctx, opEnd, right)
+		if err != nil {
+			return start, rhs, err
+		}
+		return end, op.build(o, rhs), nil
+	}
+	return p.atom(ctx, start)
+}
@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/runes"
+)
+
+func token[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
+	return fn.Preceded(runes.Space0, p)
+}
+
+func testParser() nom.ParseFn[rune, string] {
+	binary := func(op rune, l, r string) string { return fmt.Sprintf("(%v %v %v)", l, string(op), r) }
+	prefix := func(op rune, e string) string { return fmt.Sprintf("(%v%v)", string(op), e) }
+	postfix := func(op rune, e string) string { return fmt.Sprintf("(%v%v)", e, string(op)) }
+	ternary := func(c, a, b string) string { return fmt.Sprintf("(%v ? %v : %v)", c, a, b) }
+
+	var p nom.ParseFn[rune, string]
+	atom := token(fn.Alt(
+		runes.Digit1,
+		func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+			return runes.SurroundedBy('(', ')', p)(ctx, start)
+		},
+	))
+	p = New[rune, rune](atom).
+		Ternary(1, token(runes.Rune('?')), token(runes.Rune(':')), ternary).
+		Infix(NonAssoc, 2, token(runes.Rune('<')), binary).
+		Infix(Left, 3, token(runes.OneOf("+-")), binary).
+		Infix(Left, 4, token(runes.OneOf("*/")), binary).
+		Infix(Right, 6, token(runes.Rune('^')), binary).
+		Prefix(5, token(runes.Rune('-')), prefix).
+		Postfix(7, token(runes.Rune('!')), postfix).
+		ParseFn()
+	return p
+}
+
+func TestParser(t *testing.T) {
+	p := testParser()
+	for _, tc := range []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "1", want: "1"},
+		{in: "1 - 2 - 3", want: "((1 - 2) - 3)"},
+		{in: "1 - 2 * 3 / 4", want: "(1 - ((2 * 3) / 4))"},
+		{in: "2 ^ 3 ^ 4", want: "(2 ^ (3 ^ 4))"},
+		{in: "-2 ^ 2", want: "(-(2 ^ 2))"},
+		{in: "-2 * 3", want: "((-2) * 3)"},
+		{in: "-3!", want: "(-(3!))"},
+		{in: "(1 + 2) * 3", want: "((1 + 2) * 3)"},
+		{in: "1 < 2 ? 3 : 4 ? 5 : 6", want: "((1 < 2) ? 3 : (4 ? 5 : 6))"},
+		{in: "1 < 2 < 3", wantErr: true},
+		{in: "1 +", wantErr: true},
+		{in: "*", wantErr: true},
+	} {
+		_, got, err := p(context.Background(), runes.Cursor(tc.in))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("Parser(%q) error = %v, want error %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parser(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSharedOperatorPrefix(t *testing.T) {
+	binary := func(op string, l, r string) string { return fmt.Sprintf("(%v %v %v)", l, op, r) }
+	p := New[rune, string](token(runes.Digit1)).
+		Infix(Left, 1, token(runes.Tag("*")), binary).
+		Infix(Right, 2, token(runes.Tag("**")), binary).
+		ParseFn()
+
+	_, got, err := p(context.Background(), runes.Cursor("2 * 3 ** 2"))
+	if err != nil {
+		t.Fatalf("Parser() error = %v", err)
+	}
+	if want := "(2 * (3 ** 2))"; got != want {
+		t.Errorf("Parser() = %q, want %q", got, want)
+	}
+}
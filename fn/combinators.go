@@ -3,7 +3,6 @@ package fn
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/jtdubs/go-nom"
 	"github.com/jtdubs/go-nom/trace"
@@ -16,24 +15,29 @@ func zero[T any]() T {
 
 func Alt[C comparable, T any](ps ...nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		perr := nom.Errorf(start, "fn.Alt", "no alternatives matched")
 		for _, p := range ps {
+			if err := nom.Step(ctx, start, "fn.Alt"); err != nil {
+				return start, zero[T](), err
+			}
 			end, result, err := p(ctx, start)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, zero[T](), err
+				}
+				perr.Merge(err)
 				continue
 			}
 			return end, result, nil
 		}
-		return start, zero[T](), errors.New("no alternatives matched")
+		return start, zero[T](), perr
 	})
 }
 
 func Expect[C comparable](want C) nom.ParseFn[C, C] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[C]) (nom.Cursor[C], C, error) {
-		if start.EOF() {
-			return start, zero[C](), fmt.Errorf("got %v, want EOF", start.Read())
-		}
-		if got := start.Read(); got != want {
-			return start, zero[C](), fmt.Errorf("got %v, want %v", got, want)
+		if start.EOF() || start.Read() != want {
+			return start, zero[C](), nom.Expected(start, "fn.Expect", nom.Describe(want))
 		}
 		return start.Next(), want, nil
 	})
@@ -61,6 +65,9 @@ func Opt[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
 		end, res, err := p(ctx, start)
 		if err != nil {
+			if !nom.Recover(ctx, err) {
+				return start, zero[T](), err
+			}
 			return start, zero[T](), nil
 		}
 		return end, res, nil
@@ -90,7 +97,7 @@ func Verify[C comparable, T any](p nom.ParseFn[C, T], checkFn func(T) bool) nom.
 			return start, zero[T](), err
 		}
 		if !checkFn(res) {
-			return start, zero[T](), errors.New("Verify() check failed")
+			return start, zero[T](), nom.Errorf(start, "fn.Verify", "check failed")
 		}
 		return end, res, nil
 	})
@@ -110,9 +117,12 @@ func Not[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
 		_, _, err := p(ctx, start)
 		if err != nil {
+			if !nom.IsRecoverable(err) {
+				return start, zero[T](), err
+			}
 			return start, zero[T](), nil
 		}
-		return start, zero[T](), errors.New("Not()")
+		return start, zero[T](), nom.Errorf(start, "fn.Not", "unexpected match")
 	})
 }
 
@@ -158,14 +168,10 @@ func Discard[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, struct{}]
 
 func Satisfy[C comparable](testFn func(C) bool) nom.ParseFn[C, C] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[C]) (nom.Cursor[C], C, error) {
-		if start.EOF() {
-			return start, zero[C](), errors.New("EOF")
-		}
-		got := start.Read()
-		if !testFn(got) {
-			return start, zero[C](), fmt.Errorf("%v does not satisfy test", got)
+		if start.EOF() || !testFn(start.Read()) {
+			return start, zero[C](), nom.Expected(start, "fn.Satisfy")
 		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
@@ -199,3 +205,68 @@ func Spanning[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, nom.Span[
 		return
 	})
 }
+
+func Furthest[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		ctx = nom.WithFurthestFailure(ctx)
+		end, res, err := p(ctx, start)
+		if err != nil {
+			if nom.Recover(ctx, err) {
+				if furthest := nom.FurthestFailure(ctx); furthest != nil {
+					err = furthest
+				}
+			}
+			return start, zero[T](), err
+		}
+		return end, res, nil
+	})
+}
+
+func Expecting[C comparable, T any](what string, p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		end, res, err := p(ctx, start)
+		if err != nil {
+			if !nom.IsRecoverable(err) {
+				return start, zero[T](), err
+			}
+			return start, zero[T](), nom.Expected(start, "fn.Expecting", what).Wrap(err)
+		}
+		return end, res, nil
+	})
+}
+
+func Cut[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		end, res, err := p(ctx, start)
+		if err != nil {
+			var perr *nom.ParseError
+			if errors.As(err, &perr) && perr.Kind == nom.Incomplete {
+				return start, zero[T](), err
+			}
+			if errors.As(err, &perr) {
+				failure := *perr
+				failure.Kind = nom.Failure
+				return start, zero[T](), &failure
+			}
+			return start, zero[T](), &nom.ParseError{
+				Kind:     nom.Failure,
+				Position: start.Position(),
+				Parser:   "fn.Cut",
+				Causes:   []error{err},
+			}
+		}
+		return end, res, nil
+	})
+}
+
+// Recursive marks p as a recursive rule, limiting how deeply it may nest.
+func Recursive[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		ctx, leave, err := nom.Descend(ctx, start, "fn.Recursive")
+		if err != nil {
+			return start, zero[T](), err
+		}
+		defer leave()
+		return p(ctx, start)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -166,3 +167,113 @@ func TestDiscard(t *testing.T) {
 	validate(t, "Discard(%q)", p, "Jello", 0, struct{}{}, true)
 	validate(t, "Discard(%q)", p, "", 0, struct{}{}, true)
 }
+
+func TestAltExpected(t *testing.T) {
+	p := Alt(Expect('H'), Expect('I'), Alt(Expect('J'), Expect('H')))
+	_, _, err := p(context.Background(), nom.NewCursor([]rune("Kello")))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Alt() err = %v, want *nom.ParseError", err)
+	}
+	if perr.Position != 0 {
+		t.Errorf("Alt() err position = %v, want 0", perr.Position)
+	}
+	if diff := cmp.Diff([]string{"'H'", "'I'", "'J'"}, perr.Expected); diff != "" {
+		t.Errorf("Alt() err expected unexpected diff (-want +got):\n%v\n", diff)
+	}
+	if len(perr.Causes) != 3 {
+		t.Errorf("Alt() err causes = %v, want 3", len(perr.Causes))
+	}
+}
+
+func TestExpecting(t *testing.T) {
+	p := Expecting("greeting", Seq(Expect('H'), Expect('i')))
+	validate(t, "Expecting(%q)", p, "Hi", 2, []rune("Hi"), false)
+	validate(t, "Expecting(%q)", p, "Ho", 0, []rune(""), true)
+	_, _, err := p(context.Background(), nom.NewCursor([]rune("Ho")))
+	if diff := cmp.Diff([]string{"greeting"}, nom.Expectations(err)); diff != "" {
+		t.Errorf("Expecting() err expected unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
+
+func TestFurthest(t *testing.T) {
+	p := Furthest(Terminated(Many0(Seq(Expect('a'), Expect('b'))), EOF[rune]))
+	validate(t, "Furthest(%q)", p, "abab", 4, [][]rune{[]rune("ab"), []rune("ab")}, false)
+	validate(t, "Furthest(%q)", p, "ababax", 0, nil, true)
+
+	_, _, err := p(context.Background(), nom.NewCursor([]rune("ababax")))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Furthest() err = %v, want *nom.ParseError", err)
+	}
+	if perr.Position != 5 {
+		t.Errorf("Furthest() err position = %v, want 5", perr.Position)
+	}
+	if diff := cmp.Diff([]string{"'b'"}, perr.Expected); diff != "" {
+		t.Errorf("Furthest() err expected unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
+
+func TestCut(t *testing.T) {
+	p := Alt(Preceded(Expect('('), Cut(Expect('H'))), Expect('J'))
+	validate(t, "Cut(%q)", p, "(H", 2, 'H', false)
+	validate(t, "Cut(%q)", p, "J", 1, 'J', false)
+	validate(t, "Cut(%q)", p, "(J", 0, rune(0), true)
+
+	_, _, err := p(context.Background(), nom.NewCursor([]rune("(J")))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Cut() err = %v, want *nom.ParseError", err)
+	}
+	if perr.Kind != nom.Failure || perr.Position != 1 {
+		t.Errorf("Cut() err = %v at %v, want Failure at 1", perr.Kind, perr.Position)
+	}
+
+	validate(t, "Many0(%q)", Many0(Preceded(Expect('('), Cut(Expect('H')))), "(H(J", 0, nil, true)
+	validate(t, "SeparatedList0(%q)", SeparatedList0(Expect(','), Preceded(Expect('('), Cut(Expect('H')))), "(H,(J", 0, nil, true)
+	validate(t, "ManyTill(%q)", ManyTill(Preceded(Expect('('), Cut(Expect('H'))), Expect('.')), "(H(J.", 0, tuple([]rune{}, rune(0)), true)
+	validate(t, "Opt(%q)", Opt(Preceded(Expect('('), Cut(Expect('H')))), "(J", 0, rune(0), true)
+}
+
+func nested(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], int, error) {
+	return Recursive(Alt(
+		Map(Preceded(Expect('('), Terminated(nested, Expect(')'))), func(n int) int { return n + 1 }),
+		Success[rune](0),
+	))(ctx, start)
+}
+
+func TestRecursive(t *testing.T) {
+	validate(t, "Recursive(%q)", nested, "((()))", 6, 3, false)
+	validate(t, "Recursive(%q)", nested, "", 0, 0, false)
+
+	deep := strings.Repeat("(", 2000) + strings.Repeat(")", 2000)
+	validate(t, "Recursive(deep)", nested, deep, 0, 0, true)
+
+	_, _, err := nested(context.Background(), nom.NewCursor([]rune(deep)))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) || perr.Kind != nom.Failure || perr.Position != nom.DefaultMaxDepth {
+		t.Errorf("Recursive(deep) = %v, want failure at %v", err, nom.DefaultMaxDepth)
+	}
+
+	ctx := nom.WithMaxDepth(context.Background(), 3)
+	if _, got, err := nested(ctx, nom.NewCursor([]rune("(())"))); err != nil || got != 2 {
+		t.Errorf("Recursive(%q) with max depth 3 = %v, %v, want 2", "(())", got, err)
+	}
+	if _, _, err := nested(ctx, nom.NewCursor([]rune("((()))"))); !strings.Contains(fmt.Sprint(err), "nesting too deep") {
+		t.Errorf("Recursive(%q) with max depth 3 = %v, want nesting too deep", "((()))", err)
+	}
+
+	// Sibling rules see the depth of their parent, not of earlier siblings.
+	var depths []int
+	var list nom.ParseFn[rune, []int]
+	list = Recursive(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], []int, error) {
+		depths = append(depths, nom.Depth(ctx))
+		return Preceded(Expect('['), Terminated(Many0(Value(0, list)), Expect(']')))(ctx, start)
+	})
+	if _, _, err := list(context.Background(), nom.NewCursor([]rune("[[][[]]]"))); err != nil {
+		t.Fatalf("Recursive(%q) = %v", "[[][[]]]", err)
+	}
+	if want := []int{1, 2, 3, 2, 3, 4, 3, 2}; !cmp.Equal(depths, want) {
+		t.Errorf("Recursive(%q) depths = %v, want %v", "[[][[]]]", depths, want)
+	}
+}
@@ -0,0 +1,103 @@
+package fn
+
+import (
+	"context"
+	"math"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+// fold applies p up to max times, accumulating its results with f. It returns
+// the number of matches, and the error that stopped the repetition, if any.
+// If p's name is given, matches that consume no input are reported as errors,
+// as they would repeat until max.
+func fold[C comparable, T, A any](ctx context.Context, parser, name string, start nom.Cursor[C], max int, p nom.ParseFn[C, T], acc A, f func(A, T) A) (nom.Cursor[C], A, int, error) {
+	end := start
+	for n := 0; n < max; n++ {
+		if err := nom.Step(ctx, end, parser); err != nil {
+			return end, acc, n, err
+		}
+		next, res, err := p(ctx, end)
+		if err != nil {
+			return end, acc, n, err
+		}
+		if name != "" && next.Position() == end.Position() {
+			return end, acc, n, noProgress(end, parser, name)
+		}
+		end, acc = next, f(acc, res)
+	}
+	return end, acc, max, nil
+}
+
+func Count[C comparable, T any](count int, p nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	if count < 0 {
+		count = 0
+	}
+	// count may far exceed the input, so only preallocate a little and let
+	// append grow the results from there.
+	size := count
+	if size > 64 {
+		size = 64
+	}
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []T, error) {
+		end, results, n, err := fold(ctx, "fn.Count", "", start, count, p, make([]T, 0, size), func(results []T, res T) []T { return append(results, res) })
+		if n < count {
+			if !nom.Recover(ctx, err) {
+				return start, nil, err
+			}
+			return start, nil, nom.Errorf(start, "fn.Count", "got %v matches, want %v", n, count).Wrap(err)
+		}
+		return end, results, nil
+	})
+}
+
+func Fold0[C comparable, T, A any](p nom.ParseFn[C, T], init func() A, f func(A, T) A) nom.ParseFn[C, A] {
+	name := trace.NameOf(p)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], A, error) {
+		end, acc, _, err := fold(ctx, "fn.Fold0", name, start, math.MaxInt, p, init(), f)
+		if err != nil && !nom.Recover(ctx, err) {
+			return start, zero[A](), err
+		}
+		return end, acc, nil
+	})
+}
+
+func Fold1[C comparable, T, A any](p nom.ParseFn[C, T], init func() A, f func(A, T) A) nom.ParseFn[C, A] {
+	name := trace.NameOf(p)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], A, error) {
+		end, acc, n, err := fold(ctx, "fn.Fold1", name, start, math.MaxInt, p, init(), f)
+		if n == 0 {
+			return start, zero[A](), err
+		}
+		if err != nil && !nom.Recover(ctx, err) {
+			return start, zero[A](), err
+		}
+		return end, acc, nil
+	})
+}
+
+func FoldN[C comparable, T, A any](min, max int, p nom.ParseFn[C, T], init func() A, f func(A, T) A) nom.ParseFn[C, A] {
+	name := trace.NameOf(p)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], A, error) {
+		end, acc, n, err := fold(ctx, "fn.FoldN", name, start, max, p, init(), f)
+		if err != nil && !nom.Recover(ctx, err) {
+			return start, zero[A](), err
+		}
+		if n < min {
+			return start, zero[A](), nom.Errorf(start, "fn.FoldN", "got %v matches, want [%v, %v]", n, min, max).Wrap(err)
+		}
+		return end, acc, nil
+	})
+}
+
+func ManyCount[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, int] {
+	name := trace.NameOf(p)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], int, error) {
+		end, n, _, err := fold(ctx, "fn.ManyCount", name, start, math.MaxInt, p, 0, func(n int, _ T) int { return n + 1 })
+		if err != nil && !nom.Recover(ctx, err) {
+			return start, 0, err
+		}
+		return end, n, nil
+	})
+}
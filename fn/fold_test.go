@@ -0,0 +1,57 @@
+package fn
+
+import (
+	"math"
+	"testing"
+	"unicode"
+)
+
+func TestCount(t *testing.T) {
+	p := Count(3, Expect('H'))
+	validate(t, "Count(%q)", p, "HHHHH", 3, []rune("HHH"), false)
+	validate(t, "Count(%q)", p, "HHH", 3, []rune("HHH"), false)
+	validate(t, "Count(%q)", p, "HHJ", 0, []rune(""), true)
+	validate(t, "Count(%q)", p, "", 0, []rune(""), true)
+	validate(t, "Count(%q)", Count(0, Expect('H')), "HHH", 0, []rune(""), false)
+	validate(t, "Count(MaxInt)(%q)", Count(math.MaxInt, Expect('H')), "HHH", 0, []rune(""), true)
+}
+
+func sum() int { return 0 }
+
+func add(acc int, r rune) int { return acc + int(r-'0') }
+
+func TestFold0(t *testing.T) {
+	p := Fold0(Satisfy(unicode.IsDigit), sum, add)
+	validate(t, "Fold0(%q)", p, "123x", 3, 6, false)
+	validate(t, "Fold0(%q)", p, "9", 1, 9, false)
+	validate(t, "Fold0(%q)", p, "x", 0, 0, false)
+	validate(t, "Fold0(%q)", p, "", 0, 0, false)
+
+	// Each parse starts from a fresh accumulator.
+	last := Fold0(Any[rune], func() []rune { return nil }, func(acc []rune, r rune) []rune { return append(acc, r) })
+	validate(t, "Fold0(%q)", last, "ab", 2, []rune("ab"), false)
+	validate(t, "Fold0(%q)", last, "cd", 2, []rune("cd"), false)
+}
+
+func TestFold1(t *testing.T) {
+	p := Fold1(Satisfy(unicode.IsDigit), sum, add)
+	validate(t, "Fold1(%q)", p, "123x", 3, 6, false)
+	validate(t, "Fold1(%q)", p, "9", 1, 9, false)
+	validate(t, "Fold1(%q)", p, "x", 0, 0, true)
+	validate(t, "Fold1(%q)", p, "", 0, 0, true)
+}
+
+func TestFoldN(t *testing.T) {
+	p := FoldN(2, 3, Satisfy(unicode.IsDigit), sum, add)
+	validate(t, "FoldN(%q)", p, "1234", 3, 6, false)
+	validate(t, "FoldN(%q)", p, "12x", 2, 3, false)
+	validate(t, "FoldN(%q)", p, "1x", 0, 0, true)
+	validate(t, "FoldN(%q)", p, "", 0, 0, true)
+}
+
+func TestManyCount(t *testing.T) {
+	p := ManyCount(Expect('H'))
+	validate(t, "ManyCount(%q)", p, "HHHJ", 3, 3, false)
+	validate(t, "ManyCount(%q)", p, "J", 0, 0, false)
+	validate(t, "ManyCount(%q)", p, "", 0, 0, false)
+}
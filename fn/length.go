@@ -0,0 +1,116 @@
+package fn
+
+import (
+	"context"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+// length runs count, and checks that the items it counts are present.
+func length[C comparable, N nom.Integer](ctx context.Context, parser string, count nom.ParseFn[C, N], start nom.Cursor[C]) (nom.Cursor[C], int, error) {
+	mid, n, err := count(ctx, start)
+	if err != nil {
+		return start, 0, err
+	}
+	if n < 0 || uint64(n) > uint64(^uint(0)>>1) {
+		return start, 0, nom.Errorf(start, parser, "invalid length %v", n)
+	}
+	if !mid.Has(int(n)) {
+		return start, 0, nom.EOFError(mid, parser, int(n))
+	}
+	return mid, int(n), nil
+}
+
+func LengthData[C comparable, N nom.Integer](count nom.ParseFn[C, N]) nom.ParseFn[C, []C] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []C, error) {
+		mid, n, err := length(ctx, "fn.LengthData", count, start)
+		if err != nil {
+			return start, nil, err
+		}
+		end := mid.At(mid.Position() + n)
+		return end, mid.To(end), nil
+	})
+}
+
+func LengthValue[C comparable, N nom.Integer, T any](count nom.ParseFn[C, N], p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		mid, n, err := length(ctx, "fn.LengthValue", count, start)
+		if err != nil {
+			return start, zero[T](), err
+		}
+		// The whole payload is present, so p sees complete input.
+		end, res, err := p(ctx, mid.Limit(n).WithPartial(false))
+		if err != nil {
+			return start, zero[T](), err
+		}
+		if rest := mid.Position() + n - end.Position(); rest > 0 {
+			return start, zero[T](), nom.Errorf(end, "fn.LengthValue", "%v trailing items in payload", rest)
+		}
+		return mid.At(end.Position()), res, nil
+	})
+}
+
+func TLV[C, K comparable, N nom.Integer, T any](tag nom.ParseFn[C, K], count nom.ParseFn[C, N], values map[K]nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	ps := make(map[K]nom.ParseFn[C, T], len(values))
+	for k, p := range values {
+		ps[k] = LengthValue(count, p)
+	}
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		mid, k, err := tag(ctx, start)
+		if err != nil {
+			return start, zero[T](), err
+		}
+		p, ok := ps[k]
+		if !ok {
+			return start, zero[T](), nom.Errorf(start, "fn.TLV", "unknown tag %v", nom.Describe(k))
+		}
+		end, res, err := p(ctx, mid)
+		if err != nil {
+			return start, zero[T](), err
+		}
+		return end, res, nil
+	})
+}
+
+// Within runs p on a cursor confined to the next n items.
+func Within[C comparable, T any](n int, p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		if !start.Has(n) {
+			return start, zero[T](), nom.EOFError(start, "fn.Within", n)
+		}
+		end, res, err := p(ctx, start.Limit(n).WithPartial(false))
+		if err != nil {
+			return start, zero[T](), err
+		}
+		return start.At(end.Position()), res, nil
+	})
+}
+
+// Sub runs p on a cursor confined to the items recognized by delim.
+func Sub[C comparable, D, T any](delim nom.ParseFn[C, D], p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		mid, _, err := delim(ctx, start)
+		if err != nil {
+			return start, zero[T](), err
+		}
+		end, res, err := p(ctx, start.Limit(mid.Position()-start.Position()).WithPartial(false))
+		if err != nil {
+			return start, zero[T](), err
+		}
+		return start.At(end.Position()), res, nil
+	})
+}
+
+func AllConsuming[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		end, res, err := p(ctx, start)
+		if err != nil {
+			return start, zero[T](), err
+		}
+		if !end.EOF() {
+			return start, zero[T](), nom.Expected(end, "fn.AllConsuming", "EOF")
+		}
+		return end, res, nil
+	})
+}
@@ -0,0 +1,91 @@
+package fn
+
+import (
+	"context"
+	"math"
+	"testing"
+	"unicode"
+
+	"github.com/jtdubs/go-nom"
+)
+
+func digit(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], int, error) {
+	return Map(Satisfy(unicode.IsDigit), func(r rune) int { return int(r - '0') })(ctx, start)
+}
+
+func TestLengthData(t *testing.T) {
+	p := LengthData(digit)
+	validate(t, "LengthData(%q)", p, "3abcd", 4, []rune("abc"), false)
+	validate(t, "LengthData(%q)", p, "0abcd", 1, []rune(""), false)
+	validate(t, "LengthData(%q)", p, "5abcd", 0, []rune(""), true)
+	validate(t, "LengthData(%q)", p, "abcd", 0, []rune(""), true)
+
+	_, _, err := p(context.Background(), nom.NewCursor([]rune("5ab")).WithPartial(true))
+	if needed, ok := nom.IsIncomplete(err); !ok || needed != 3 {
+		t.Errorf("LengthData(%q) on partial input = %v, want 3 items needed", "5ab", err)
+	}
+
+	// Lengths near MaxInt must not overflow past the end of the input.
+	huge := Value(math.MaxInt, Any[rune])
+	validate(t, "LengthData(MaxInt)(%q)", LengthData(huge), "xab", 0, []rune(""), true)
+	validate(t, "LengthValue(MaxInt)(%q)", LengthValue(huge, Rest[rune]), "xab", 0, []rune(""), true)
+}
+
+func TestLengthValue(t *testing.T) {
+	p := LengthValue(digit, Many0(Expect('a')))
+	validate(t, "LengthValue(%q)", p, "2aaaa", 3, []rune("aa"), false)
+	validate(t, "LengthValue(%q)", p, "0aaaa", 1, []rune(""), false)
+	validate(t, "LengthValue(%q)", p, "3aab", 0, []rune(""), true)
+	validate(t, "LengthValue(%q)", p, "5aa", 0, []rune(""), true)
+
+	// Nested payloads are confined to the innermost length.
+	nested := LengthValue(digit, Pair(LengthValue(digit, Rest[rune]), Rest[rune]))
+	validate(t, "LengthValue(LengthValue)(%q)", nested, "52abcde", 6, nom.Tuple[[]rune, []rune]{A: []rune("ab"), B: []rune("cd")}, false)
+
+	// A payload parser that needs more than the payload fails.
+	validate(t, "LengthValue(Take(3))(%q)", LengthValue(digit, Take[rune](3)), "2abc", 0, []rune(""), true)
+}
+
+func TestTLV(t *testing.T) {
+	p := TLV(Any[rune], digit, map[rune]nom.ParseFn[rune, string]{
+		's': Map(Rest[rune], func(rs []rune) string { return string(rs) }),
+		'n': Value("number", Many1(Satisfy(unicode.IsDigit))),
+	})
+	validate(t, "TLV(%q)", p, "s3abcdef", 5, "abc", false)
+	validate(t, "TLV(%q)", p, "n2123", 4, "number", false)
+	validate(t, "TLV(%q)", p, "n2a1", 0, "", true)
+	validate(t, "TLV(%q)", p, "x1a", 0, "", true)
+	validate(t, "TLV(%q)", p, "", 0, "", true)
+}
+
+func TestWithin(t *testing.T) {
+	p := Within(3, Many0(Expect('a')))
+	validate(t, "Within(%q)", p, "aaaaa", 3, []rune("aaa"), false)
+	validate(t, "Within(%q)", p, "aabaa", 2, []rune("aa"), false)
+	validate(t, "Within(%q)", p, "aa", 0, []rune(""), true)
+	validate(t, "Within(AllConsuming)(%q)", Within(3, AllConsuming(Many0(Expect('a')))), "aabaa", 0, []rune(""), true)
+	validate(t, "Within(MaxInt)(%q)", Preceded(Expect('x'), Within(math.MaxInt, Rest[rune])), "xaa", 0, []rune(""), true)
+
+	// Positions inside the child cursor are absolute.
+	span := Preceded(Expect('x'), Within(2, Spanning(Rest[rune])))
+	_, got, err := span(context.Background(), nom.NewCursor([]rune("xabc")))
+	if err != nil || got.Start.Position() != 1 || got.End.Position() != 3 || string(got.Value()) != "ab" {
+		t.Errorf("Within(Spanning) = %v, %v, want Span(1...3)", got, err)
+	}
+}
+
+func TestSub(t *testing.T) {
+	field := Many1(Satisfy(func(r rune) bool { return r != ',' }))
+	p := Sub(field, Many0(Expect('a')))
+	validate(t, "Sub(%q)", p, "aaa,aa", 3, []rune("aaa"), false)
+	validate(t, "Sub(%q)", p, "aab,aa", 2, []rune("aa"), false)
+	validate(t, "Sub(%q)", p, ",aa", 0, []rune(""), true)
+	validate(t, "Sub(AllConsuming)(%q)", Sub(field, AllConsuming(Many0(Expect('a')))), "aab,aa", 0, []rune(""), true)
+}
+
+func TestAllConsuming(t *testing.T) {
+	p := AllConsuming(Many0(Expect('a')))
+	validate(t, "AllConsuming(%q)", p, "aaa", 3, []rune("aaa"), false)
+	validate(t, "AllConsuming(%q)", p, "", 0, []rune(""), false)
+	validate(t, "AllConsuming(%q)", p, "aab", 0, []rune(""), true)
+}
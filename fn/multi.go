@@ -2,42 +2,80 @@ package fn
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/jtdubs/go-nom"
 	"github.com/jtdubs/go-nom/trace"
 )
 
+// noProgress reports a parser, named when it was wrapped, that succeeded
+// without consuming input, which would otherwise be repeated forever.
+func noProgress[C comparable](at nom.Cursor[C], parser, name string) error {
+	err := nom.Errorf(at, parser, "parser %v did not consume input", name)
+	err.Kind = nom.Failure
+	return err
+}
+
 func Many0[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	name := trace.NameOf(p)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (end nom.Cursor[C], results []T, err error) {
 		end = start
 		for {
-			var res T
-			end, res, err = p(ctx, end)
+			if err := nom.Step(ctx, end, "fn.Many0"); err != nil {
+				return start, nil, err
+			}
+			var (
+				next nom.Cursor[C]
+				res  T
+			)
+			next, res, err = p(ctx, end)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				return end, results, nil
 			}
+			if next.Position() == end.Position() {
+				return start, nil, noProgress(end, "fn.Many0", name)
+			}
+			end = next
 			results = append(results, res)
 		}
 	})
 }
 
 func Many1[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	name := trace.NameOf(p)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []T, error) {
 		end, res, err := p(ctx, start)
 		if err != nil {
 			return start, nil, err
 		}
-		var results []T
-		for err == nil {
+		if end.Position() == start.Position() {
+			return start, nil, noProgress(start, "fn.Many1", name)
+		}
+		results := []T{res}
+		for {
+			if err := nom.Step(ctx, end, "fn.Many1"); err != nil {
+				return start, nil, err
+			}
+			next, res, err := p(ctx, end)
+			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
+				return end, results, nil
+			}
+			if next.Position() == end.Position() {
+				return start, nil, noProgress(end, "fn.Many1", name)
+			}
+			end = next
 			results = append(results, res)
-			end, res, err = p(ctx, end)
 		}
-		return end, results, nil
 	})
 }
 
 func ManyN[C comparable, T any](min, max int, p nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	name := trace.NameOf(p)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []T, error) {
 		end := start
 		var (
@@ -45,59 +83,104 @@ func ManyN[C comparable, T any](min, max int, p nom.ParseFn[C, T]) nom.ParseFn[C
 			err     error
 		)
 		for len(results) < max {
-			var res T
-			end, res, err = p(ctx, end)
+			if err := nom.Step(ctx, end, "fn.ManyN"); err != nil {
+				return start, nil, err
+			}
+			var (
+				next nom.Cursor[C]
+				res  T
+			)
+			next, res, err = p(ctx, end)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				break
 			}
+			if next.Position() == end.Position() {
+				return start, nil, noProgress(end, "fn.ManyN", name)
+			}
+			end = next
 			results = append(results, res)
 		}
 		if len(results) < min {
-			return start, nil, fmt.Errorf("ManyN() got %v, wanted [%v, %v]", len(results), min, max)
+			return start, nil, nom.Errorf(start, "fn.ManyN", "got %v matches, want [%v, %v]", len(results), min, max).Wrap(err)
 		}
 		return end, results, nil
 	})
 }
 
 func ManyTill[C comparable, T, U any](f nom.ParseFn[C, T], g nom.ParseFn[C, U]) nom.ParseFn[C, nom.Tuple[[]T, U]] {
+	name := trace.NameOf(f)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (end nom.Cursor[C], res nom.Tuple[[]T, U], err error) {
 		end = start
 		for {
+			if err := nom.Step(ctx, end, "fn.ManyTill"); err != nil {
+				return start, zero[nom.Tuple[[]T, U]](), err
+			}
 			var (
 				u U
 				t T
 			)
-			if end, u, err = g(ctx, end); err == nil {
+			var gerr, ferr error
+			if end, u, gerr = g(ctx, end); gerr == nil {
 				res.B = u
 				return
 			}
-			if end, t, err = f(ctx, end); err != nil {
+			if !nom.Recover(ctx, gerr) {
+				return start, zero[nom.Tuple[[]T, U]](), gerr
+			}
+			before := end
+			if end, t, ferr = f(ctx, end); ferr != nil {
+				err = ferr
+				if nom.IsRecoverable(ferr) {
+					err = nom.Expected(end, "fn.ManyTill").Merge(gerr, ferr)
+				}
 				end = start
 				res.A = nil
 				return
 			}
+			if end.Position() == before.Position() {
+				return start, zero[nom.Tuple[[]T, U]](), noProgress(before, "fn.ManyTill", name)
+			}
 			res.A = append(res.A, t)
 		}
 	})
 }
 
 func SeparatedList0[C comparable, T, D any](delim nom.ParseFn[C, D], values nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	name := trace.NameOf(values)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []T, error) {
 		var results []T
 		end, res, err := values(ctx, start)
 		if err != nil {
+			if !nom.Recover(ctx, err) {
+				return start, nil, err
+			}
 			return start, nil, nil
 		}
 		results = append(results, res)
 		for {
+			if err := nom.Step(ctx, end, "fn.SeparatedList0"); err != nil {
+				return start, nil, err
+			}
 			delimEnd, _, err := delim(ctx, end)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				return end, results, nil
 			}
 			valueEnd, res, err := values(ctx, delimEnd)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				return end, results, nil
 			}
+			if valueEnd.Position() == end.Position() {
+				return start, nil, noProgress(end, "fn.SeparatedList0", name)
+			}
 			end = valueEnd
 			results = append(results, res)
 		}
@@ -105,6 +188,7 @@ func SeparatedList0[C comparable, T, D any](delim nom.ParseFn[C, D], values nom.
 }
 
 func SeparatedList1[C comparable, T, D any](delim nom.ParseFn[C, D], values nom.ParseFn[C, T]) nom.ParseFn[C, []T] {
+	name := trace.NameOf(values)
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []T, error) {
 		end, res, err := values(ctx, start)
 		if err != nil {
@@ -112,14 +196,26 @@ func SeparatedList1[C comparable, T, D any](delim nom.ParseFn[C, D], values nom.
 		}
 		results := []T{res}
 		for {
+			if err := nom.Step(ctx, end, "fn.SeparatedList1"); err != nil {
+				return start, nil, err
+			}
 			delimEnd, _, err := delim(ctx, end)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				return end, results, nil
 			}
 			valueEnd, res, err := values(ctx, delimEnd)
 			if err != nil {
+				if !nom.Recover(ctx, err) {
+					return start, nil, err
+				}
 				return end, results, nil
 			}
+			if valueEnd.Position() == end.Position() {
+				return start, nil, noProgress(end, "fn.SeparatedList1", name)
+			}
 			end = valueEnd
 			results = append(results, res)
 		}
@@ -1,7 +1,11 @@
 package fn
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jtdubs/go-nom"
 )
@@ -80,3 +84,88 @@ func TestManyTill(t *testing.T) {
 func tuple[A, B any](a A, b B) nom.Tuple[A, B] {
 	return nom.Tuple[A, B]{A: a, B: b}
 }
+
+func TestIncomplete(t *testing.T) {
+	for name, p := range map[string]nom.ParseFn[rune, []rune]{
+		"Many0":    Many0(Expect('H')),
+		"Alt":      Alt(Seq(Expect('H'), Expect('H'), Expect('H')), Seq(Expect('J'))),
+		"Opt":      Opt(Seq(Expect('H'), Expect('H'), Expect('H'))),
+		"Expects":  Expects([]rune("HHH")),
+		"ManyTill": Map(ManyTill(Expect('H'), Expect('.')), func(t nom.Tuple[[]rune, rune]) []rune { return t.A }),
+	} {
+		start := nom.NewCursor([]rune("HH")).WithPartial(true)
+		_, _, err := p(context.Background(), start)
+		if needed, ok := nom.IsIncomplete(err); !ok || needed != 1 {
+			t.Errorf("%v(%q) err = %v, want incomplete needing 1", name, "HH", err)
+		}
+
+		if _, _, err := p(context.Background(), start.WithPartial(false)); err != nil {
+			if _, ok := nom.IsIncomplete(err); ok {
+				t.Errorf("%v(%q) err = %v, want complete", name, "HH", err)
+			}
+		}
+	}
+}
+
+func TestNoProgress(t *testing.T) {
+	empty := Opt(Expect('H'))
+	for name, p := range map[string]nom.ParseFn[rune, int]{
+		"Many0":          Map(Many0(empty), func(rs []rune) int { return len(rs) }),
+		"Many1":          Map(Many1(empty), func(rs []rune) int { return len(rs) }),
+		"ManyN":          Map(ManyN(0, 10, empty), func(rs []rune) int { return len(rs) }),
+		"ManyTill":       Map(ManyTill(empty, Expect('X')), func(res nom.Tuple[[]rune, rune]) int { return len(res.A) }),
+		"SeparatedList0": Map(SeparatedList0(Opt(Expect(',')), empty), func(rs []rune) int { return len(rs) }),
+		"SeparatedList1": Map(SeparatedList1(Opt(Expect(',')), empty), func(rs []rune) int { return len(rs) }),
+		"Fold0":          Fold0(empty, func() int { return 0 }, func(n int, _ rune) int { return n + 1 }),
+		"FoldN":          FoldN(0, 10, empty, func() int { return 0 }, func(n int, _ rune) int { return n + 1 }),
+		"ManyCount":      ManyCount(empty),
+	} {
+		_, _, err := p(context.Background(), nom.NewCursor([]rune("HHJ")))
+		var perr *nom.ParseError
+		if !errors.As(err, &perr) || perr.Kind != nom.Failure || perr.Parser != "fn."+name || perr.Position != 2 {
+			t.Errorf("%v(Opt) = %v, want failure at 2", name, err)
+			continue
+		}
+		if !strings.Contains(perr.Message, "fn.Opt") {
+			t.Errorf("%v(Opt) error %q does not name fn.Opt", name, perr.Message)
+		}
+	}
+
+	// Count asks for exactly count matches, so they may consume no input.
+	validate(t, "Count(%q)", Count(2, empty), "J", 0, []rune{0, 0}, false)
+}
+
+func TestCancellation(t *testing.T) {
+	in := nom.NewCursor([]rune("HHHHHHHHHH"))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		p    nom.ParseFn[rune, []rune]
+		want error
+	}{
+		{"Many0", cancelled, Many0(Expect('H')), context.Canceled},
+		{"Alt", cancelled, Alt(Many1(Expect('J')), Many1(Expect('H'))), context.Canceled},
+		{"SeparatedList0", expired, SeparatedList0(Opt(Expect(',')), Expect('H')), context.DeadlineExceeded},
+		{"Many1", nom.WithFuel(context.Background(), 5), Many1(Expect('H')), nom.ErrFuelExhausted},
+		{"Alt(Many0)", nom.WithFuel(context.Background(), 5), Alt(Many0(Expect('H'))), nom.ErrFuelExhausted},
+	} {
+		end, _, err := tc.p(tc.ctx, in)
+		if !errors.Is(err, tc.want) || !nom.IsCancelled(err) || nom.IsRecoverable(err) {
+			t.Errorf("%v = %v, want %v", tc.name, err, tc.want)
+		}
+		if end.Position() != 0 {
+			t.Errorf("%v cursor = %v, want 0", tc.name, end.Position())
+		}
+	}
+
+	// Enough fuel lets the parse finish.
+	_, got, err := Many0(Expect('H'))(nom.WithFuel(context.Background(), 11), in)
+	if err != nil || len(got) != 10 {
+		t.Errorf("Many0 with fuel = %q, %v, want 10 matches", string(got), err)
+	}
+}
@@ -0,0 +1,47 @@
+package fn
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+// Ref is a parser that can be used before the parser it refers to is built,
+// so that recursive grammars can be assembled from values. The zero Ref is
+// unset; Parse fails until Set is called.
+type Ref[C comparable, T any] struct {
+	p atomic.Pointer[nom.ParseFn[C, T]]
+}
+
+func (r *Ref[C, T]) Set(p nom.ParseFn[C, T]) {
+	p = Recursive(p)
+	r.p.Store(&p)
+}
+
+func (r *Ref[C, T]) Parse(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		p := r.p.Load()
+		if p == nil {
+			err := nom.Errorf(start, "fn.Ref", "reference used before it was set")
+			err.Kind = nom.Failure
+			return start, zero[T](), err
+		}
+		return (*p)(ctx, start)
+	})(ctx, start)
+}
+
+// Lazy builds its parser on first use, so that it may refer to parsers that
+// are not yet built when Lazy is called.
+func Lazy[C comparable, T any](build func() nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	var (
+		once sync.Once
+		p    nom.ParseFn[C, T]
+	)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		once.Do(func() { p = Recursive(build()) })
+		return p(ctx, start)
+	})
+}
@@ -0,0 +1,49 @@
+package fn
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jtdubs/go-nom"
+)
+
+func TestRef(t *testing.T) {
+	var list Ref[rune, int]
+	validate(t, "Ref(%q)", list.Parse, "[]", 0, 0, true)
+
+	// A list is a bracketed, comma separated list of lists.
+	rules := map[string]nom.ParseFn[rune, int]{}
+	rules["items"] = Map(SeparatedList0(Expect(','), list.Parse), func(ns []int) int {
+		total := len(ns)
+		for _, n := range ns {
+			total += n
+		}
+		return total
+	})
+	list.Set(Preceded(Expect('['), Terminated(rules["items"], Expect(']'))))
+
+	validate(t, "Ref(%q)", list.Parse, "[]", 2, 0, false)
+	validate(t, "Ref(%q)", list.Parse, "[[],[[]]]", 9, 3, false)
+	validate(t, "Ref(%q)", list.Parse, "[[],[[]]", 0, 0, true)
+
+	deep := strings.Repeat("[", 2000) + strings.Repeat("]", 2000)
+	_, _, err := list.Parse(context.Background(), nom.NewCursor([]rune(deep)))
+	if nom.IsRecoverable(err) || !strings.Contains(err.Error(), "nesting too deep") {
+		t.Errorf("Ref(deep) = %v, want nesting too deep", err)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var value nom.ParseFn[rune, int]
+	pair := Lazy(func() nom.ParseFn[rune, int] {
+		return Map(Surrounded(Expect('('), Expect(')'), Pair(value, Preceded(Expect(','), value))), func(t nom.Tuple[int, int]) int {
+			return t.A + t.B
+		})
+	})
+	value = Alt(pair, Map(Satisfy(func(r rune) bool { return r >= '0' && r <= '9' }), func(r rune) int { return int(r - '0') }))
+
+	validate(t, "Lazy(%q)", value, "7", 1, 7, false)
+	validate(t, "Lazy(%q)", value, "(1,(2,3))", 9, 6, false)
+	validate(t, "Lazy(%q)", value, "(1,)", 0, 0, true)
+}
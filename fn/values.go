@@ -2,7 +2,6 @@ package fn
 
 import (
 	"context"
-	"errors"
 
 	"github.com/jtdubs/go-nom"
 	"github.com/jtdubs/go-nom/trace"
@@ -11,13 +10,26 @@ import (
 func Any[C comparable](ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], C, error) {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], C, error) {
 		if start.EOF() {
-			return start, zero[C](), errors.New("EOF")
+			return start, zero[C](), nom.Expected(start, "fn.Any")
 		}
 		got := start.Read()
 		return start.Next(), got, nil
 	})(ctx, start)
 }
 
+func Take[C comparable](n int) nom.ParseFn[C, []C] {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[C]) (nom.Cursor[C], []C, error) {
+		if n < 0 {
+			return start, nil, nom.Errorf(start, "fn.Take", "cannot take %v items", n)
+		}
+		if !start.Has(n) {
+			return start, nil, nom.EOFError(start, "fn.Take", n)
+		}
+		end := start.At(start.Position() + n)
+		return end, start.To(end), nil
+	})
+}
+
 func Rest[C comparable](ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []C, error) {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], []C, error) {
 		return start.ToEOF(), start.Rest(), nil
@@ -33,8 +45,18 @@ func RestLen[C comparable](ctx context.Context, start nom.Cursor[C]) (nom.Cursor
 func EOF[C comparable](ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], struct{}, error) {
 	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], struct{}, error) {
 		if !start.EOF() {
-			return start, struct{}{}, errors.New("EOF() got slice, want EOF")
+			return start, struct{}{}, nom.Expected(start, "fn.EOF", "EOF")
 		}
 		return start, struct{}{}, nil
 	})(ctx, start)
 }
+
+func Release[C comparable, T any](p nom.ParseFn[C, T]) nom.ParseFn[C, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[C]) (nom.Cursor[C], T, error) {
+		end, res, err := p(ctx, start)
+		if err == nil {
+			end.Release()
+		}
+		return end, res, err
+	})
+}
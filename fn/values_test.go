@@ -1,6 +1,13 @@
 package fn
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jtdubs/go-nom"
+)
 
 func TestAny(t *testing.T) {
 	p := Any[rune]
@@ -29,3 +36,20 @@ func TestEOF(t *testing.T) {
 	validate(t, "EOF(%q)", p, "Hello", 0, struct{}{}, true)
 	validate(t, "EOF(%q)", p, "", 0, struct{}{}, false)
 }
+
+func TestTake(t *testing.T) {
+	p := Take[rune](3)
+	validate(t, "Take(%q)", p, "Hello", 3, []rune("Hel"), false)
+	validate(t, "Take(%q)", p, "Hel", 3, []rune("Hel"), false)
+	validate(t, "Take(%q)", p, "He", 0, []rune(""), true)
+	validate(t, "Take(%q)", p, "", 0, []rune(""), true)
+	validate(t, "Take(-1)(%q)", Preceded(Any[rune], Take[rune](-1)), "Hello", 0, []rune(nil), true)
+}
+
+func TestReleaseBacktrack(t *testing.T) {
+	p := Alt(Value(1, Terminated(Many0(Release(Expect[byte]('a'))), Expect[byte]('c'))), Value(2, Expect[byte]('a')))
+	_, _, err := p(context.Background(), nom.NewStreamCursor(bytes.NewReader([]byte("aab")).Read))
+	if nom.IsRecoverable(err) || !errors.Is(err, nom.ErrReleased) {
+		t.Errorf("Alt() after Release() err = %v, want failure wrapping %v", err, nom.ErrReleased)
+	}
+}
@@ -0,0 +1,77 @@
+package nom
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type contextKey int
+
+const (
+	furthestKey contextKey = iota
+	fuelKey
+	depthKey
+	maxDepthKey
+)
+
+type furthestFailure struct {
+	mu  sync.Mutex
+	err *ParseError
+}
+
+func WithFurthestFailure(ctx context.Context) context.Context {
+	return context.WithValue(ctx, furthestKey, &furthestFailure{})
+}
+
+func FurthestFailure(ctx context.Context) *ParseError {
+	ff, ok := ctx.Value(furthestKey).(*furthestFailure)
+	if !ok {
+		return nil
+	}
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	return ff.err
+}
+
+func RecordFailure(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	ff, ok := ctx.Value(furthestKey).(*furthestFailure)
+	if !ok {
+		return
+	}
+	ff.mu.Lock()
+	defer ff.mu.Unlock()
+	ff.record(err)
+}
+
+func (ff *furthestFailure) record(err error) {
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		return
+	}
+	switch {
+	case ff.err == nil || perr.Position > ff.err.Position:
+		// Copy so that merging expectations never mutates an error owned by a parser.
+		clone := *perr
+		clone.Expected = append([]string(nil), perr.Expected...)
+		ff.err = &clone
+	case perr.Position == ff.err.Position && ff.err != perr:
+		ff.err.Expect(perr.Expected...)
+	}
+	for _, cause := range perr.Causes {
+		ff.record(cause)
+	}
+}
+
+// Recover reports whether a parser may try an alternative after err, recording
+// err as a candidate furthest failure if so.
+func Recover(ctx context.Context, err error) bool {
+	if !IsRecoverable(err) {
+		return false
+	}
+	RecordFailure(ctx, err)
+	return true
+}
@@ -1,5 +1,5 @@
 module github.com/jtdubs/go-nom
 
-go 1.19
+go 1.20
 
 require github.com/google/go-cmp v0.5.9
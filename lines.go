@@ -0,0 +1,227 @@
+package nom
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type Location struct {
+	Line, Column int
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("%v:%v", l.Line, l.Column)
+}
+
+type LineOptions struct {
+	// TabWidth is the distance between tab stops; values below 2 count a tab as a single column.
+	TabWidth int
+	// CR treats a carriage return that is not followed by a newline as a line terminator.
+	CR bool
+}
+
+// lineIndex records the offset at which each line starts. It is built
+// incrementally as input is fed to it, and shared by every cursor derived
+// from the same input.
+type lineIndex struct {
+	opts    LineOptions
+	mu      sync.Mutex
+	starts  []int
+	dropped int
+	scanned int
+	cr      bool
+	done    bool
+}
+
+func newLineIndex(opts LineOptions) *lineIndex {
+	return &lineIndex{opts: opts, starts: []int{0}}
+}
+
+func (li *lineIndex) feed(items any) {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	li.scan(items)
+}
+
+func (li *lineIndex) finish() {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	li.end()
+}
+
+// build scans a complete input, unless it has been scanned already.
+func (li *lineIndex) build(items any) {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	if !li.done {
+		li.scan(items)
+		li.end()
+	}
+}
+
+func (li *lineIndex) scan(items any) {
+	switch items := items.(type) {
+	case []rune:
+		feedLines(li, items)
+	case []byte:
+		feedLines(li, items)
+	}
+}
+
+func feedLines[E rune | byte](li *lineIndex, items []E) {
+	for _, e := range items {
+		if li.cr && li.opts.CR && e != '\n' {
+			li.starts = append(li.starts, li.scanned)
+		}
+		li.cr = e == '\r'
+		li.scanned++
+		if e == '\n' {
+			li.starts = append(li.starts, li.scanned)
+		}
+	}
+}
+
+func (li *lineIndex) end() {
+	if li.cr && li.opts.CR {
+		li.starts = append(li.starts, li.scanned)
+	}
+	li.cr = false
+	li.done = true
+}
+
+// release drops the starts of lines that end before offset.
+func (li *lineIndex) release(offset int) {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	n := sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset }) - 1
+	if n > 0 {
+		li.starts = append(make([]int, 0, len(li.starts)-n), li.starts[n:]...)
+		li.dropped += n
+	}
+}
+
+// line returns the 1-based line containing offset, and the offsets at which
+// that line and the following line start. next is -1 if the following line
+// has not been seen yet. line is 0 if offset is in a line that has been
+// released, which is then treated as being empty.
+func (li *lineIndex) line(offset int) (line, start, next int) {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	i := sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset })
+	if i == 0 {
+		return 0, offset, offset
+	}
+	next = -1
+	if i < len(li.starts) {
+		next = li.starts[i]
+	}
+	return li.dropped + i, li.starts[i-1], next
+}
+
+func columnOf[E rune | byte](line []E, opts LineOptions) int {
+	col := 1
+	for _, e := range line {
+		if e == '\t' && opts.TabWidth > 1 {
+			col = ((col-1)/opts.TabWidth+1)*opts.TabWidth + 1
+		} else {
+			col++
+		}
+	}
+	return col
+}
+
+func (c Cursor[T]) WithLineOptions(opts LineOptions) Cursor[T] {
+	if c.stream != nil {
+		c.lines = c.stream.setLineOptions(opts)
+		return c
+	}
+	c.lines = newLineIndex(opts)
+	return c
+}
+
+func (c Cursor[T]) index() *lineIndex {
+	if c.stream != nil {
+		return c.stream.index()
+	}
+	lines := c.lines
+	if lines == nil {
+		lines = newLineIndex(LineOptions{})
+	}
+	lines.build(any(c.buffer))
+	return lines
+}
+
+// lineOf returns the line containing the cursor, reading ahead in streams
+// until the start of the following line is known.
+func (c Cursor[T]) lineOf() (lines *lineIndex, line, start, next int) {
+	lines = c.index()
+	line, start, next = lines.line(c.offset)
+	for next == -1 && c.stream != nil && c.stream.fetchMore() {
+		line, start, next = lines.line(c.offset)
+	}
+	return
+}
+
+// text returns the retained items in [from, to), and how many items before
+// them have already been released.
+func (c Cursor[T]) text(from, to int) ([]T, int) {
+	released := 0
+	if c.stream != nil {
+		if base := c.stream.retained(); to < base {
+			return nil, to - from
+		} else if from < base {
+			released, from = base-from, base
+		}
+	}
+	return c.slice(from, to), released
+}
+
+func (c Cursor[T]) Location() Location {
+	lines := c.index()
+	line, start, _ := lines.line(c.offset)
+	if line == 0 {
+		return Location{}
+	}
+	text, released := c.text(start, c.offset)
+	switch b := any(text).(type) {
+	case []rune:
+		return Location{line, released + columnOf(b, lines.opts)}
+	case []byte:
+		return Location{line, released + columnOf(b, lines.opts)}
+	default:
+		return Location{line, c.offset - start + 1}
+	}
+}
+
+func (c Cursor[T]) LineStart() Cursor[T] {
+	_, _, start, _ := c.lineOf()
+	return c.At(start)
+}
+
+func (c Cursor[T]) Line() []T {
+	_, _, start, end := c.lineOf()
+	if end == -1 {
+		end = c.length()
+	}
+	line, _ := c.text(start, end)
+	for len(line) > 0 && isLineTerminator(line[len(line)-1]) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+func isLineTerminator(v any) bool {
+	switch v := v.(type) {
+	case rune:
+		return v == '\n' || v == '\r'
+	case byte:
+		return v == '\n' || v == '\r'
+	default:
+		return false
+	}
+}
+
+func (s Span[T]) Location() (start, end Location) {
+	return s.Start.Location(), s.End.Location()
+}
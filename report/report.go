@@ -0,0 +1,140 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jtdubs/go-nom"
+)
+
+const hexRowWidth = 16
+
+func Write[C comparable](w io.Writer, filename string, at nom.Cursor[C], err error) error {
+	var perr *nom.ParseError
+	end := at.Next()
+	if errors.As(err, &perr) {
+		at, end = at.At(perr.Position), at.At(perr.Position).Next()
+		if perr.End > perr.Position {
+			end = at.At(perr.End)
+		}
+	}
+	return WriteSpan(w, filename, nom.Span[C]{Start: at, End: end}, err)
+}
+
+func WriteSpan[C comparable](w io.Writer, filename string, span nom.Span[C], err error) error {
+	var sb strings.Builder
+	switch s := any(span).(type) {
+	case nom.Span[rune]:
+		writeSource(&sb, filename, s, err)
+	case nom.Span[byte]:
+		writeHex(&sb, filename, s, err)
+	default:
+		fmt.Fprintf(&sb, "%v:%v: %v\n", filename, span.Start.Position(), message(err))
+		writeExpected(&sb, err)
+	}
+	_, werr := io.WriteString(w, sb.String())
+	return werr
+}
+
+func String[C comparable](filename string, at nom.Cursor[C], err error) string {
+	var sb strings.Builder
+	Write(&sb, filename, at, err)
+	return sb.String()
+}
+
+func writeSource(sb *strings.Builder, filename string, span nom.Span[rune], err error) {
+	start, end := span.Location()
+	fmt.Fprintf(sb, "%v:%v: %v\n", filename, start, message(err))
+
+	line := span.Start.Line()
+	gutter := fmt.Sprint(start.Line)
+	fmt.Fprintf(sb, " %v | %v\n", gutter, string(line))
+
+	// Offsets into the line, clamped so that multi-line spans underline to end of line.
+	lineStart := span.Start.LineStart().Position()
+	from := span.Start.Position() - lineStart
+	to := span.End.Position() - lineStart
+	if end.Line != start.Line || to > len(line) {
+		to = len(line)
+	}
+	if to <= from {
+		to = from + 1
+	}
+
+	fmt.Fprintf(sb, " %v | ", strings.Repeat(" ", len(gutter)))
+	for i := 0; i < from; i++ {
+		// Reuse tabs so the caret lines up however the terminal expands them.
+		if i < len(line) && line[i] == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	sb.WriteString("^")
+	sb.WriteString(strings.Repeat("~", to-from-1))
+	sb.WriteString("\n")
+	writeExpected(sb, err)
+}
+
+func writeHex(sb *strings.Builder, filename string, span nom.Span[byte], err error) {
+	pos := span.Start.Position()
+	fmt.Fprintf(sb, "%v:0x%x: %v\n", filename, pos, message(err))
+
+	rowStart := pos - pos%hexRowWidth
+	row := span.Start.At(rowStart).To(span.Start.At(rowStart + hexRowWidth))
+
+	fmt.Fprintf(sb, " %08x |", rowStart)
+	for _, b := range row {
+		fmt.Fprintf(sb, " %02x", b)
+	}
+	sb.WriteString(strings.Repeat("   ", hexRowWidth-len(row)))
+	sb.WriteString(" |")
+	for _, b := range row {
+		if b >= 0x20 && b < 0x7f {
+			sb.WriteByte(b)
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+	sb.WriteString("|\n")
+
+	from := pos - rowStart
+	to := span.End.Position() - rowStart
+	if to > hexRowWidth {
+		to = hexRowWidth
+	}
+	if to <= from {
+		to = from + 1
+	}
+	fmt.Fprintf(sb, " %v |%v ^^", strings.Repeat(" ", 8), strings.Repeat("   ", from))
+	sb.WriteString(strings.Repeat("~~~", to-from-1))
+	sb.WriteString("\n")
+	writeExpected(sb, err)
+}
+
+func message(err error) string {
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		return fmt.Sprintf("error: %v", err)
+	}
+	msg := perr.Message
+	if msg == "" {
+		msg = "parse failed"
+	}
+	if perr.Parser != "" {
+		return fmt.Sprintf("error: %v (in %v)", msg, perr.Parser)
+	}
+	return fmt.Sprintf("error: %v", msg)
+}
+
+func writeExpected(sb *strings.Builder, err error) {
+	switch expected := nom.Expectations(err); len(expected) {
+	case 0:
+	case 1:
+		fmt.Fprintf(sb, "expected %v\n", expected[0])
+	default:
+		fmt.Fprintf(sb, "expected one of %v\n", strings.Join(expected, ", "))
+	}
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/bytes"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/runes"
+)
+
+func TestStringRunes(t *testing.T) {
+	start := runes.Cursor("let x = 1;\n\tlet y = ?;\n")
+	p := fn.Preceded(fn.Many0(fn.Satisfy(func(r rune) bool { return r != '?' })), fn.Alt(runes.Tag("42"), runes.Digit1))
+	_, _, err := p(context.Background(), start)
+	got := String("input.txt", start, err)
+	want := "input.txt:2:10: error: no alternatives matched (in fn.Alt)\n" +
+		" 2 | \tlet y = ?;\n" +
+		"   | \t        ^\n" +
+		"expected one of \"42\", digit\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("String() unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
+
+func TestStringSpan(t *testing.T) {
+	start := runes.Cursor("abc defgh ij")
+	span := nom.Span[rune]{Start: start.At(4), End: start.At(9)}
+	var sb strings.Builder
+	if err := WriteSpan(&sb, "f", span, nom.Errorf(span.Start, "", "bad word")); err != nil {
+		t.Fatalf("WriteSpan() unexpected error: %v", err)
+	}
+	want := "f:1:5: error: bad word\n" +
+		" 1 | abc defgh ij\n" +
+		"   |     ^~~~~\n"
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("WriteSpan() unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
+
+func TestStringErrorSpan(t *testing.T) {
+	start := runes.Cursor("x = 3000;")
+	_, _, err := fn.Preceded(runes.Tag("x = "), runes.Int[int8])(context.Background(), start)
+	got := String("f", start, err)
+	want := "f:1:5: error: 3000 out of range for int8 (in runes.Int)\n" +
+		" 1 | x = 3000;\n" +
+		"   |     ^~~~\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("String() unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
+
+func TestStringBytes(t *testing.T) {
+	start := bytes.Cursor([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x01"))
+	p := fn.Preceded(bytes.Tag("\x89PNG\r\n\x1a\n\x00\x00\x00\x0d"), bytes.Tag("IDAT"))
+	_, _, err := p(context.Background(), start)
+	got := String("image.png", start, err)
+	want := "image.png:0xc: error: parse failed (in bytes.Tag)\n" +
+		" 00000000 | 89 50 4e 47 0d 0a 1a 0a 00 00 00 0d 49 48 44 52 |.PNG........IHDR|\n" +
+		"          |                                     ^^\n" +
+		"expected \"IDAT\"\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("String() unexpected diff (-want +got):\n%v\n", diff)
+	}
+}
@@ -0,0 +1,231 @@
+package runes
+
+import (
+	"context"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+type TabPolicy int
+
+const (
+	SpacesOnly TabPolicy = iota
+	TabsOnly
+	TabsAndSpaces
+)
+
+type IndentOptions struct {
+	Tabs TabPolicy
+	// TabWidth is the distance between tab stops when tabs and spaces are
+	// mixed; values below 1 select 8.
+	TabWidth int
+}
+
+// indentState is the indentation of each enclosing block, innermost last.
+type indentState struct {
+	opts   IndentOptions
+	levels []int
+}
+
+func indentOf(ctx context.Context) indentState {
+	if st, ok := ctx.Value(IndentKey).(indentState); ok {
+		return st
+	}
+	return indentState{levels: []int{0}}
+}
+
+func (st indentState) level() int {
+	return st.levels[len(st.levels)-1]
+}
+
+func (st indentState) push(level int) indentState {
+	st.levels = append(st.levels[:len(st.levels):len(st.levels)], level)
+	return st
+}
+
+func WithIndentOptions(ctx context.Context, opts IndentOptions) context.Context {
+	st := indentOf(ctx)
+	st.opts = opts
+	return context.WithValue(ctx, IndentKey, st)
+}
+
+func indentError(parser string, at nom.Cursor[rune], format string, args ...any) error {
+	err := nom.Errorf(at, parser, format, args...)
+	err.Kind = nom.Failure
+	return err
+}
+
+// indentation measures the whitespace at start, which should be the start
+// of a line.
+func indentation(parser string, st indentState, start nom.Cursor[rune]) (nom.Cursor[rune], int, error) {
+	width := st.opts.TabWidth
+	if width < 1 {
+		width = 8
+	}
+	end, col := start, 0
+	for !end.EOF() {
+		switch end.Read() {
+		case ' ':
+			if st.opts.Tabs == TabsOnly {
+				return start, 0, indentError(parser, end, "inconsistent indentation: space, want tabs")
+			}
+			col++
+		case '\t':
+			if st.opts.Tabs == SpacesOnly {
+				return start, 0, indentError(parser, end, "inconsistent indentation: tab, want spaces")
+			}
+			if st.opts.Tabs == TabsOnly {
+				col++
+			} else {
+				col = (col/width + 1) * width
+			}
+		default:
+			return end, col, nil
+		}
+		end = end.Next()
+	}
+	return end, col, nil
+}
+
+// skipBlank skips lines holding nothing but spaces and tabs.
+func skipBlank(start nom.Cursor[rune]) nom.Cursor[rune] {
+	for {
+		end := start
+		for !end.EOF() && IsSpace(end.Read()) {
+			end = end.Next()
+		}
+		if end.Read() == '\r' {
+			end = end.Next()
+		}
+		if end.EOF() || end.Read() != '\n' {
+			return start
+		}
+		start = end.Next()
+	}
+}
+
+func atLineStart(c nom.Cursor[rune]) bool {
+	return c.EOF() || c.LineStart().Position() == c.Position()
+}
+
+// SameIndent consumes indentation matching that of the enclosing block,
+// skipping any blank lines before it.
+func SameIndent(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], struct{}, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], struct{}, error) {
+		st := indentOf(ctx)
+		end, col, err := indentation("runes.SameIndent", st, skipBlank(start))
+		if err != nil {
+			return start, struct{}{}, err
+		}
+		if col != st.level() {
+			return start, struct{}{}, nom.Errorf(end, "runes.SameIndent", "got indentation %v, want %v", col, st.level())
+		}
+		return end, struct{}{}, nil
+	})(ctx, start)
+}
+
+// Indented runs p after indentation deeper than that of the enclosing block,
+// which becomes the indentation of the block p is in.
+func Indented[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], T, error) {
+		st := indentOf(ctx)
+		body, col, err := indentation("runes.Indented", st, skipBlank(start))
+		if err != nil {
+			var zero T
+			return start, zero, err
+		}
+		if col <= st.level() {
+			var zero T
+			return start, zero, nom.Expected(body, "runes.Indented", "indented line")
+		}
+		end, res, err := p(context.WithValue(ctx, IndentKey, st.push(col)), body)
+		if err != nil {
+			var zero T
+			return start, zero, err
+		}
+		return end, res, nil
+	})
+}
+
+// Block parses one or more lines with p, all indented to the same depth, and
+// deeper than the enclosing block, if there is one. p parses the content of a
+// line, and may itself contain nested blocks; anything after it up to the end
+// of the line must be spaces. The block ends at the first line indented less
+// deeply. Block may start after the indentation of its first line, as it does
+// within Indented, which then sets the depth of the block.
+func Block[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, []T] {
+	const parser = "runes.Block"
+	eol := fn.Preceded(Space0, fn.Alt(EOL, fn.Value('\n', fn.EOF[rune])))
+	name := trace.NameOf(p)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], []T, error) {
+		st := indentOf(ctx)
+		line, resumed := skipBlank(start), !atLineStart(start)
+		if resumed {
+			line = start.LineStart()
+		}
+		body, level, err := indentation(parser, st, line)
+		if err != nil {
+			return start, nil, err
+		}
+		if resumed && body.Position() != start.Position() {
+			return start, nil, nom.Expected(start, parser, "indented block")
+		}
+		// Indentation consumed by Indented has already entered this level.
+		entered := resumed && level == st.level()
+		if nested := len(st.levels) > 1; nested && level <= st.level() && !entered || body.EOF() {
+			return start, nil, nom.Expected(body, parser, "indented block")
+		}
+		inner := ctx
+		if !entered {
+			inner = context.WithValue(ctx, IndentKey, st.push(level))
+		}
+
+		var results []T
+		for {
+			if err := nom.Step(ctx, body, parser); err != nil {
+				return start, nil, err
+			}
+			end, res, err := p(inner, body)
+			if err != nil {
+				return start, nil, err
+			}
+			// An empty line would be parsed again and again.
+			if end.Position() == body.Position() {
+				err := nom.Errorf(body, parser, "parser %v did not consume input", name)
+				err.Kind = nom.Failure
+				return start, nil, err
+			}
+			if !atLineStart(end) {
+				if end, _, err = eol(ctx, end); err != nil {
+					return start, nil, err
+				}
+			}
+			results = append(results, res)
+
+			line = skipBlank(end)
+			var col int
+			if body, col, err = indentation(parser, st, line); err != nil {
+				return start, nil, err
+			}
+			switch {
+			case body.EOF() || col < level && st.contains(col):
+				return end, results, nil
+			case col < level:
+				return start, nil, indentError(parser, body, "unindent does not match any outer indentation level")
+			case col > level:
+				return start, nil, indentError(parser, body, "unexpected indent")
+			}
+		}
+	})
+}
+
+func (st indentState) contains(level int) bool {
+	for _, l := range st.levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
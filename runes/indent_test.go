@@ -0,0 +1,120 @@
+package runes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+)
+
+type entry struct {
+	Key      string
+	Value    string
+	Children []entry
+}
+
+func parseEntry(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], entry, error) {
+	value := fn.Map(fn.Preceded(Space1, Recognize(fn.Many1(NoneOf("\r\n")))), func(v string) entry { return entry{Value: v} })
+	children := fn.Map(fn.Preceded(EOL, Block(parseEntry)), func(es []entry) entry { return entry{Children: es} })
+	return fn.Map(
+		fn.Pair(fn.Terminated(Alpha1, Rune(':')), fn.Alt(value, children)),
+		func(t nom.Tuple[string, entry]) entry {
+			t.B.Key = t.A
+			return t.B
+		},
+	)(ctx, start)
+}
+
+func TestBlock(t *testing.T) {
+	in := strings.Join([]string{
+		"server:",
+		"  host: example.com",
+		"",
+		"  tls:",
+		"    cert: a.pem",
+		"    key: a.key",
+		"  port: 443",
+		"debug: yes",
+		"",
+	}, "\n")
+	want := []entry{
+		{Key: "server", Children: []entry{
+			{Key: "host", Value: "example.com"},
+			{Key: "tls", Children: []entry{
+				{Key: "cert", Value: "a.pem"},
+				{Key: "key", Value: "a.key"},
+			}},
+			{Key: "port", Value: "443"},
+		}},
+		{Key: "debug", Value: "yes"},
+	}
+	end, got, err := Block(parseEntry)(context.Background(), Cursor(in))
+	if err != nil {
+		t.Fatalf("Block() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Block() unexpected diff (-want +got):\n%v\n", diff)
+	}
+	if !end.EOF() {
+		t.Errorf("Block() cursor = %v, want EOF", end)
+	}
+}
+
+func TestBlockErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name, in string
+		opts     IndentOptions
+		pos      int
+		message  string
+	}{
+		{"unexpected indent", "a:\n  b: 1\n    c: 2\n", IndentOptions{}, 14, "unexpected indent"},
+		{"bad dedent", "a:\n    b: 1\n  c: 2\n", IndentOptions{}, 14, "unindent does not match"},
+		{"tab", "a:\n\tb: 1\n", IndentOptions{}, 3, "tab, want spaces"},
+		{"space", "a:\n\t b: 1\n", IndentOptions{Tabs: TabsOnly}, 4, "space, want tabs"},
+		{"empty block", "a:\nb: 1\n", IndentOptions{}, 0, ""},
+	} {
+		ctx := WithIndentOptions(context.Background(), tc.opts)
+		_, _, err := Block(parseEntry)(ctx, Cursor(tc.in))
+		var perr *nom.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%v: Block() = %v, want error", tc.name, err)
+			continue
+		}
+		if tc.message == "" {
+			continue
+		}
+		if perr.Kind != nom.Failure || perr.Position != tc.pos || !strings.Contains(perr.Message, tc.message) {
+			t.Errorf("%v: Block() = %v at %v, want %q at %v", tc.name, err, perr.Position, tc.message, tc.pos)
+		}
+	}
+
+	// A line parser that consumes nothing would never finish the block.
+	_, _, err := Block(fn.Opt(Tag("y")))(context.Background(), Cursor("x\n"))
+	if perr := (*nom.ParseError)(nil); !errors.As(err, &perr) || perr.Kind != nom.Failure || !strings.Contains(perr.Message, "did not consume input") {
+		t.Errorf("Block(Opt) = %v, want no progress failure", err)
+	}
+
+	// Tabs and spaces may be mixed when allowed, with tabs advancing to tab stops.
+	ctx := WithIndentOptions(context.Background(), IndentOptions{Tabs: TabsAndSpaces, TabWidth: 4})
+	if _, got, err := Block(parseEntry)(ctx, Cursor("a:\n\tb: 1\n    c: 2\n")); err != nil || len(got) != 1 || len(got[0].Children) != 2 {
+		t.Errorf("Block() with mixed indentation = %v, %v", got, err)
+	}
+}
+
+func TestIndented(t *testing.T) {
+	p := fn.Pair(fn.Terminated(Alpha1, EOL), Indented(fn.Pair(Alpha1, fn.Preceded(EOL, SameIndent))))
+	validate(t, "Indented(%q)", p, "a\n  b\n  c", 8, nom.Tuple[string, nom.Tuple[string, struct{}]]{A: "a", B: nom.Tuple[string, struct{}]{A: "b"}}, false)
+	validate(t, "Indented(%q)", p, "a\n  b\n c", 0, nom.Tuple[string, nom.Tuple[string, struct{}]]{}, true)
+	validate(t, "Indented(%q)", p, "a\nb\n", 0, nom.Tuple[string, nom.Tuple[string, struct{}]]{}, true)
+	// A Block within Indented takes its depth from Indented.
+	block := fn.Preceded(fn.Terminated(Alpha1, EOL), Indented(Block(Alpha1)))
+	validate(t, "Indented(Block)(%q)", block, "a\n  b\n  c\nd", 10, []string{"b", "c"}, false)
+	validate(t, "Indented(Block)(%q)", block, "a\n  b\n   c\nd", 0, []string(nil), true)
+	validate(t, "Indented(Block)(%q)", block, "a\n  b\n c\nd", 0, []string(nil), true)
+	validate(t, "SameIndent(%q)", SameIndent, "\n  \nx", 4, struct{}{}, false)
+	validate(t, "SameIndent(%q)", SameIndent, "  x", 0, struct{}{}, true)
+}
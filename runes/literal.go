@@ -0,0 +1,234 @@
+package runes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+type StringOptions struct {
+	// Quotes are the characters that may open a string, which must be closed
+	// by the same character. Empty selects '"'.
+	Quotes string
+	// Escape introduces escape sequences. Zero selects '\\'.
+	Escape rune
+	// Escapes maps the character following Escape to its value. Nil selects
+	// the C escapes \a \b \f \n \r \t \v \\ \' and \".
+	Escapes map[rune]rune
+	// Unicode accepts \uXXXX and \UXXXXXXXX escapes. Escaped UTF-16
+	// surrogate pairs are combined.
+	Unicode bool
+	// Hex accepts \xHH escapes.
+	Hex bool
+	// Octal accepts escapes of up to three octal digits, as in \0 or \177.
+	Octal bool
+	// Raw disables escape sequences.
+	Raw bool
+	// Multiline allows newlines in strings.
+	Multiline bool
+	// Triple accepts strings delimited by three quotes, which may span lines.
+	Triple bool
+}
+
+var defaultEscapes = map[rune]rune{
+	'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"',
+}
+
+// literalError reports malformed input in [from, to). Once a literal has
+// started there is nothing else it can be, so these are failures.
+func literalError(parser string, from, to nom.Cursor[rune], format string, args ...any) error {
+	return &nom.ParseError{
+		Kind:     nom.Failure,
+		Position: from.Position(),
+		End:      to.Position(),
+		Parser:   parser,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// hasPrefix reports whether the input at c starts with prefix.
+func hasPrefix(c nom.Cursor[rune], prefix []rune) bool {
+	for _, r := range prefix {
+		if c.EOF() || c.Read() != r {
+			return false
+		}
+		c = c.Next()
+	}
+	return true
+}
+
+// hexValue reads exactly n hex digits.
+func hexValue(parser string, escape, start nom.Cursor[rune], n int) (nom.Cursor[rune], rune, error) {
+	var value rune
+	end := start
+	for i := 0; i < n; i++ {
+		if end.EOF() && end.Partial() {
+			return start, 0, nom.EOFError(end, parser, n-i)
+		}
+		r := end.Read()
+		if end.EOF() || !IsHexDigit(r) {
+			return start, 0, literalError(parser, escape, end, "invalid escape sequence %q: want %v hex digits", string(escape.To(end)), n)
+		}
+		value = value*16 + hexDigitValue(r)
+		end = end.Next()
+	}
+	return end, value, nil
+}
+
+func hexDigitValue(r rune) rune {
+	switch {
+	case r >= 'a':
+		return r - 'a' + 10
+	case r >= 'A':
+		return r - 'A' + 10
+	default:
+		return r - '0'
+	}
+}
+
+// unescape decodes the escape sequence at start.
+func unescape(parser string, start nom.Cursor[rune], opts StringOptions, escapes map[rune]rune) (nom.Cursor[rune], rune, error) {
+	c := start.Next()
+	if c.EOF() && c.Partial() {
+		return start, 0, nom.EOFError(c, parser, 1)
+	}
+	r := c.Read()
+	if v, ok := escapes[r]; ok && !c.EOF() {
+		return c.Next(), v, nil
+	}
+	switch {
+	case opts.Unicode && (r == 'u' || r == 'U'):
+		n := 4
+		if r == 'U' {
+			n = 8
+		}
+		end, v, err := hexValue(parser, start, c.Next(), n)
+		if err != nil {
+			return start, 0, err
+		}
+		if utf16.IsSurrogate(v) && r == 'u' && hasPrefix(end, []rune{opts.Escape, 'u'}) {
+			if next, lo, err := hexValue(parser, end, end.Next().Next(), 4); err == nil {
+				if pair := utf16.DecodeRune(v, lo); pair != utf8.RuneError {
+					return next, pair, nil
+				}
+			}
+		}
+		if !utf8.ValidRune(v) {
+			return start, 0, literalError(parser, start, end, "invalid escape sequence %q: not a valid character", string(start.To(end)))
+		}
+		return end, v, nil
+	case opts.Hex && r == 'x':
+		return hexValue(parser, start, c.Next(), 2)
+	case opts.Octal && IsOctalDigit(r):
+		var v rune
+		end := c
+		for i := 0; i < 3 && !end.EOF() && IsOctalDigit(end.Read()); i++ {
+			v = v*8 + end.Read() - '0'
+			end = end.Next()
+		}
+		if v > 0xff {
+			return start, 0, literalError(parser, start, end, "invalid escape sequence %q: value out of range", string(start.To(end)))
+		}
+		return end, v, nil
+	}
+	return start, 0, literalError(parser, start, c.Next(), "invalid escape sequence %q", string(start.To(c.Next())))
+}
+
+func StringWith(opts StringOptions) nom.ParseFn[rune, string] {
+	const parser = "runes.String"
+	quotes := opts.Quotes
+	if quotes == "" {
+		quotes = `"`
+	}
+	if opts.Escape == 0 {
+		opts.Escape = '\\'
+	}
+	escapes := opts.Escapes
+	if escapes == nil {
+		escapes = defaultEscapes
+	}
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+		q := start.Read()
+		if start.EOF() || !strings.ContainsRune(quotes, q) {
+			return start, "", nom.Expected(start, parser, "string")
+		}
+		delim, multiline := []rune{q}, opts.Multiline
+		if triple := []rune{q, q, q}; opts.Triple && hasPrefix(start, triple) {
+			delim, multiline = triple, true
+		}
+		var sb strings.Builder
+		end := start.At(start.Position() + len(delim))
+		for {
+			if end.EOF() {
+				if end.Partial() {
+					return start, "", nom.EOFError(end, parser, len(delim))
+				}
+				return start, "", literalError(parser, start, end, "unterminated string")
+			}
+			if hasPrefix(end, delim) {
+				return end.At(end.Position() + len(delim)), sb.String(), nil
+			}
+			switch r := end.Read(); {
+			case r == '\n' && !multiline:
+				return start, "", literalError(parser, start, end, "unterminated string")
+			case r == opts.Escape && !opts.Raw:
+				next, v, err := unescape(parser, end, opts, escapes)
+				if err != nil {
+					return start, "", err
+				}
+				sb.WriteRune(v)
+				end = next
+			default:
+				sb.WriteRune(r)
+				end = end.Next()
+			}
+		}
+	})
+}
+
+// String parses a double-quoted string with Go's escape sequences.
+func String(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(StringWith(StringOptions{Unicode: true, Hex: true, Octal: true}))(ctx, start)
+}
+
+// RawString parses a back-quoted string without escape sequences.
+func RawString(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(StringWith(StringOptions{Quotes: "`", Raw: true, Multiline: true}))(ctx, start)
+}
+
+// Heredoc parses a here document: "<<" and a delimiter ending the line,
+// followed by lines up to one consisting of the delimiter. The result is the
+// text of the lines in between.
+func Heredoc(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+		const parser = "runes.Heredoc"
+		header := fn.Terminated(fn.Preceded(Tag("<<"), Identifier), fn.Preceded(Space0, EOL))
+		body, word, err := header(ctx, start)
+		if err != nil {
+			return start, "", err
+		}
+		for line := body; ; {
+			lineEnd := line
+			for !lineEnd.EOF() && lineEnd.Read() != '\n' {
+				lineEnd = lineEnd.Next()
+			}
+			if lineEnd.EOF() && lineEnd.Partial() {
+				return start, "", nom.EOFError(lineEnd, parser, 1)
+			}
+			if strings.TrimSuffix(string(line.To(lineEnd)), "\r") == word {
+				return lineEnd, string(body.To(line)), nil
+			}
+			if lineEnd.EOF() {
+				return start, "", literalError(parser, start, lineEnd, "unterminated here document, want %q", word)
+			}
+			line = lineEnd.Next()
+		}
+	})(ctx, start)
+}
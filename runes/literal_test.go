@@ -0,0 +1,73 @@
+package runes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jtdubs/go-nom"
+)
+
+func TestString(t *testing.T) {
+	p := String
+	validate(t, "String(%q)", p, `"hello" world`, 7, "hello", false)
+	validate(t, "String(%q)", p, `""`, 2, "", false)
+	validate(t, "String(%q)", p, `"a\tb\n\\\""`, 12, "a\tb\n\\\"", false)
+	validate(t, "String(%q)", p, `"é\U0001F600"`, 13, "é😀", false)
+	validate(t, "String(%q)", p, `"\ud83d\ude00"`, 14, "😀", false)
+	validate(t, "String(%q)", p, `"\x41\101\0"`, 12, "AA\x00", false)
+	validate(t, "String(%q)", p, `'single'`, 0, "", true)
+	validate(t, "String(%q)", p, `"unterminated`, 0, "", true)
+	validate(t, "String(%q)", p, "\"line\nbreak\"", 0, "", true)
+	validate(t, "String(%q)", p, `"\ud83d"`, 0, "", true)
+	validate(t, "String(%q)", p, `"\400"`, 0, "", true)
+	validate(t, "String(%q)", p, `"\u12"`, 0, "", true)
+}
+
+func TestStringWith(t *testing.T) {
+	single := StringWith(StringOptions{Quotes: `'"`})
+	validate(t, "StringWith(%q)", single, `'it\'s'`, 7, "it's", false)
+	validate(t, "StringWith(%q)", single, `"say 'hi'"`, 10, "say 'hi'", false)
+	validate(t, "StringWith(%q)", single, "`A`", 0, "", true)
+
+	json := StringWith(StringOptions{Escapes: map[rune]rune{'/': '/', 'n': '\n', '"': '"', '\\': '\\'}, Unicode: true})
+	validate(t, "StringWith(%q)", json, `"a\/b"`, 6, "a/b", false)
+	validate(t, "StringWith(%q)", json, `"\t"`, 0, "", true)
+
+	triple := StringWith(StringOptions{Triple: true})
+	validate(t, "StringWith(%q)", triple, "\"\"\"a \"quoted\"\nline\"\"\" x", 21, "a \"quoted\"\nline", false)
+	validate(t, "StringWith(%q)", triple, `"" x`, 2, "", false)
+	validate(t, "StringWith(%q)", triple, `"""a""`, 0, "", true)
+
+	custom := StringWith(StringOptions{Quotes: "'", Escape: '^'})
+	validate(t, "StringWith(%q)", custom, `'a^'b\n'`, 8, `a'b\n`, false)
+}
+
+func TestRawString(t *testing.T) {
+	validate(t, "RawString(%q)", RawString, "`a\\n\nb` c", 7, "a\\n\nb", false)
+	validate(t, "RawString(%q)", RawString, "`open", 0, "", true)
+}
+
+func TestStringEscapeError(t *testing.T) {
+	_, _, err := String(context.Background(), Cursor(`"abc\qdef"`))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) || perr.Kind != nom.Failure || perr.Position != 4 || perr.End != 6 {
+		t.Fatalf("String() = %v, want failure spanning 4...6", err)
+	}
+	if want := `invalid escape sequence "\\q"`; perr.Message != want {
+		t.Errorf("String() message = %q, want %q", perr.Message, want)
+	}
+
+	_, _, err = String(context.Background(), Cursor(`"abc\u`).WithPartial(true))
+	if _, ok := nom.IsIncomplete(err); !ok {
+		t.Errorf("String() on partial input = %v, want incomplete", err)
+	}
+}
+
+func TestHeredoc(t *testing.T) {
+	p := Heredoc
+	validate(t, "Heredoc(%q)", p, "<<EOF\nline 1\n  EOF\nline 3\nEOF\nrest", 29, "line 1\n  EOF\nline 3\n", false)
+	validate(t, "Heredoc(%q)", p, "<<END  \r\nEND", 12, "", false)
+	validate(t, "Heredoc(%q)", p, "<<EOF\nnever ends\n", 0, "", true)
+	validate(t, "Heredoc(%q)", p, "<<\nEOF", 0, "", true)
+}
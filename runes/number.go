@@ -0,0 +1,248 @@
+package runes
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unsafe"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+type NumberOptions struct {
+	// Prefixes accepts 0x, 0o and 0b base prefixes on integers.
+	Prefixes bool
+	// Separators accepts underscores between digits, as in 1_000.
+	Separators bool
+	// Special accepts inf, infinity and nan as floats, ignoring case.
+	Special bool
+	// Precision is the mantissa precision of big floats; 0 selects 64 bits.
+	Precision uint
+}
+
+var basePrefixes = map[rune]int{'x': 16, 'o': 8, 'b': 2}
+
+func isDigitIn(r rune, base int) bool {
+	switch base {
+	case 2:
+		return r == '0' || r == '1'
+	case 8:
+		return IsOctalDigit(r)
+	case 16:
+		return IsHexDigit(r)
+	default:
+		return IsDigit(r)
+	}
+}
+
+// scanSign consumes an optional sign, appending it to sb.
+func scanSign(start nom.Cursor[rune], sb *strings.Builder) nom.Cursor[rune] {
+	if IsSign(start.Read()) {
+		sb.WriteRune(start.Read())
+		return start.Next()
+	}
+	return start
+}
+
+// scanDigits consumes digits in base, appending them to sb without any
+// separators. Separators are only accepted between two digits.
+func scanDigits(start nom.Cursor[rune], base int, separators bool, sb *strings.Builder) (nom.Cursor[rune], int) {
+	end, n := start, 0
+	for {
+		switch r := end.Read(); {
+		case !end.EOF() && isDigitIn(r, base):
+			sb.WriteRune(r)
+			end, n = end.Next(), n+1
+		case separators && r == '_' && n > 0 && !end.Next().EOF() && isDigitIn(end.Next().Read(), base):
+			end = end.Next()
+		default:
+			return end, n
+		}
+	}
+}
+
+// scanInteger scans an integer literal, returning it in a form strconv and
+// math/big accept in the returned base.
+func scanInteger(parser string, start nom.Cursor[rune], signed bool, opts NumberOptions) (nom.Cursor[rune], string, int, error) {
+	var sb strings.Builder
+	end := start
+	if signed {
+		end = scanSign(end, &sb)
+	}
+	base := 10
+	if opts.Prefixes && end.Read() == '0' {
+		if b, ok := basePrefixes[unicode.ToLower(end.Next().Read())]; ok && isDigitIn(end.Next().Next().Read(), b) {
+			base, end = b, end.Next().Next()
+		}
+	}
+	end, n := scanDigits(end, base, opts.Separators, &sb)
+	if n == 0 {
+		return start, "", 0, nom.Expected(end, parser, "integer")
+	}
+	if end.EOF() && end.Partial() {
+		return start, "", 0, nom.EOFError(end, parser, 1)
+	}
+	return end, sb.String(), base, nil
+}
+
+// scanFloat scans a decimal floating point literal, returning it in a form
+// strconv and math/big accept.
+func scanFloat(parser string, start nom.Cursor[rune], opts NumberOptions, nan bool) (nom.Cursor[rune], string, error) {
+	var sb strings.Builder
+	end := scanSign(start, &sb)
+	if opts.Special {
+		specials := []string{"infinity", "inf"}
+		if nan && sb.Len() == 0 {
+			specials = append(specials, "nan")
+		}
+		for _, special := range specials {
+			next := end.At(end.Position() + len(special))
+			if special == strings.ToLower(string(end.To(next))) && !IsUnicodeAlphanumeric(next.Read()) {
+				// Both strconv and math/big accept these spellings.
+				if special == "nan" {
+					sb.WriteString("NaN")
+				} else {
+					sb.WriteString("Inf")
+				}
+				return next, sb.String(), nil
+			}
+		}
+	}
+	end, n := scanDigits(end, 10, opts.Separators, &sb)
+	if end.Read() == '.' && IsDigit(end.Next().Read()) {
+		sb.WriteRune('.')
+		var m int
+		end, m = scanDigits(end.Next(), 10, opts.Separators, &sb)
+		n += m
+	}
+	if n == 0 {
+		return start, "", nom.Expected(end, parser, "number")
+	}
+	if r := end.Read(); r == 'e' || r == 'E' {
+		var exp strings.Builder
+		exp.WriteRune('e')
+		if next, m := scanDigits(scanSign(end.Next(), &exp), 10, opts.Separators, &exp); m > 0 {
+			sb.WriteString(exp.String())
+			end = next
+		}
+	}
+	if end.EOF() && end.Partial() {
+		return start, "", nom.EOFError(end, parser, 1)
+	}
+	return end, sb.String(), nil
+}
+
+// numberError reports a literal that scanned correctly but could not be
+// converted, which is only possible when it is out of range.
+func numberError(parser string, start, end nom.Cursor[rune], typ string) error {
+	return &nom.ParseError{
+		Position: start.Position(),
+		End:      end.Position(),
+		Parser:   parser,
+		Message:  fmt.Sprintf("%v out of range for %v", string(start.To(end)), typ),
+	}
+}
+
+func bitSize[T any]() int {
+	var zero T
+	return 8 * int(unsafe.Sizeof(zero))
+}
+
+func IntWith[T nom.Signed](opts NumberOptions) nom.ParseFn[rune, T] {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], T, error) {
+		end, text, base, err := scanInteger("runes.Int", start, true, opts)
+		if err != nil {
+			return start, 0, err
+		}
+		n, err := strconv.ParseInt(text, base, bitSize[T]())
+		if err != nil {
+			return start, 0, numberError("runes.Int", start, end, fmt.Sprintf("%T", T(0)))
+		}
+		return end, T(n), nil
+	})
+}
+
+func Int[T nom.Signed](ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], T, error) {
+	return trace.Trace(IntWith[T](NumberOptions{}))(ctx, start)
+}
+
+func UintWith[T nom.Unsigned](opts NumberOptions) nom.ParseFn[rune, T] {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], T, error) {
+		end, text, base, err := scanInteger("runes.Uint", start, false, opts)
+		if err != nil {
+			return start, 0, err
+		}
+		n, err := strconv.ParseUint(text, base, bitSize[T]())
+		if err != nil {
+			return start, 0, numberError("runes.Uint", start, end, fmt.Sprintf("%T", T(0)))
+		}
+		return end, T(n), nil
+	})
+}
+
+func Uint[T nom.Unsigned](ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], T, error) {
+	return trace.Trace(UintWith[T](NumberOptions{}))(ctx, start)
+}
+
+func Float64With(opts NumberOptions) nom.ParseFn[rune, float64] {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], float64, error) {
+		end, text, err := scanFloat("runes.Float64", start, opts, true)
+		if err != nil {
+			return start, 0, err
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return start, 0, numberError("runes.Float64", start, end, "float64")
+		}
+		return end, f, nil
+	})
+}
+
+func Float64(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], float64, error) {
+	return trace.Trace(Float64With(NumberOptions{}))(ctx, start)
+}
+
+func BigIntWith(opts NumberOptions) nom.ParseFn[rune, *big.Int] {
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], *big.Int, error) {
+		end, text, base, err := scanInteger("runes.BigInt", start, true, opts)
+		if err != nil {
+			return start, nil, err
+		}
+		n, ok := new(big.Int).SetString(text, base)
+		if !ok {
+			return start, nil, numberError("runes.BigInt", start, end, "big.Int")
+		}
+		return end, n, nil
+	})
+}
+
+func BigInt(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], *big.Int, error) {
+	return trace.Trace(BigIntWith(NumberOptions{}))(ctx, start)
+}
+
+func BigFloatWith(opts NumberOptions) nom.ParseFn[rune, *big.Float] {
+	prec := opts.Precision
+	if prec == 0 {
+		prec = 64
+	}
+	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], *big.Float, error) {
+		// big.Float has no NaN, so only infinities are special.
+		end, text, err := scanFloat("runes.BigFloat", start, opts, false)
+		if err != nil {
+			return start, nil, err
+		}
+		f, _, err := big.ParseFloat(text, 10, prec, big.ToNearestEven)
+		if err != nil {
+			return start, nil, numberError("runes.BigFloat", start, end, "big.Float")
+		}
+		return end, f, nil
+	})
+}
+
+func BigFloat(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], *big.Float, error) {
+	return trace.Trace(BigFloatWith(NumberOptions{}))(ctx, start)
+}
@@ -0,0 +1,97 @@
+package runes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/jtdubs/go-nom"
+)
+
+func TestInt(t *testing.T) {
+	validate(t, "Int[int](%q)", Int[int], "123abc", 3, 123, false)
+	validate(t, "Int[int](%q)", Int[int], "-42", 3, -42, false)
+	validate(t, "Int[int](%q)", Int[int], "+7 ", 2, 7, false)
+	validate(t, "Int[int](%q)", Int[int], "-", 0, 0, true)
+	validate(t, "Int[int](%q)", Int[int], "x", 0, 0, true)
+	validate(t, "Int[int](%q)", Int[int], "0x10", 1, 0, false)
+	validate(t, "Int[int8](%q)", Int[int8], "-128", 4, int8(-128), false)
+	validate(t, "Int[int8](%q)", Int[int8], "128", 0, int8(0), true)
+	validate(t, "Int[int64](%q)", Int[int64], "9223372036854775808", 0, int64(0), true)
+
+	opts := NumberOptions{Prefixes: true, Separators: true}
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "0x1F", 4, 31, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "-0o17", 5, -15, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "0B101", 5, 5, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "1_000_000", 9, 1000000, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "1__0", 1, 1, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "10_", 2, 10, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "0xg", 1, 0, false)
+	validate(t, "IntWith[int](%q)", IntWith[int](opts), "_1", 0, 0, true)
+	validate(t, "IntWith[int8](%q)", IntWith[int8](opts), "0x80", 0, int8(0), true)
+}
+
+func TestUint(t *testing.T) {
+	validate(t, "Uint[uint](%q)", Uint[uint], "123abc", 3, uint(123), false)
+	validate(t, "Uint[uint](%q)", Uint[uint], "-1", 0, uint(0), true)
+	validate(t, "Uint[uint8](%q)", Uint[uint8], "255", 3, uint8(255), false)
+	validate(t, "Uint[uint8](%q)", Uint[uint8], "256", 0, uint8(0), true)
+	validate(t, "Uint[uint64](%q)", Uint[uint64], "18446744073709551615", 20, uint64(math.MaxUint64), false)
+	validate(t, "UintWith[uint16](%q)", UintWith[uint16](NumberOptions{Prefixes: true, Separators: true}), "0xff_ff", 7, uint16(0xffff), false)
+}
+
+func TestFloat64(t *testing.T) {
+	validate(t, "Float64(%q)", Float64, "3.25x", 4, 3.25, false)
+	validate(t, "Float64(%q)", Float64, "-1e3", 4, -1000.0, false)
+	validate(t, "Float64(%q)", Float64, ".5", 2, 0.5, false)
+	validate(t, "Float64(%q)", Float64, "2.5E-1", 6, 0.25, false)
+	validate(t, "Float64(%q)", Float64, "7.", 1, 7.0, false)
+	validate(t, "Float64(%q)", Float64, "7e", 1, 7.0, false)
+	validate(t, "Float64(%q)", Float64, "1e400", 0, 0.0, true)
+	validate(t, "Float64(%q)", Float64, "inf", 0, 0.0, true)
+	validate(t, "Float64(%q)", Float64, ".", 0, 0.0, true)
+
+	opts := NumberOptions{Separators: true, Special: true}
+	validate(t, "Float64With(%q)", Float64With(opts), "1_000.000_5", 11, 1000.0005, false)
+	validate(t, "Float64With(%q)", Float64With(opts), "-Infinity", 9, math.Inf(-1), false)
+	validate(t, "Float64With(%q)", Float64With(opts), "inf", 3, math.Inf(1), false)
+	validate(t, "Float64With(%q)", Float64With(opts), "info", 0, 0.0, true)
+
+	_, got, err := Float64With(opts)(context.Background(), Cursor("NaN"))
+	if err != nil || !math.IsNaN(got) {
+		t.Errorf("Float64With(%q) = %v, %v, want NaN", "NaN", got, err)
+	}
+}
+
+func TestBigNumbers(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	_, gotInt, err := BigInt(context.Background(), Cursor("-123456789012345678901234567890!"))
+	if err != nil || gotInt.Cmp(huge) != 0 {
+		t.Errorf("BigInt = %v, %v, want %v", gotInt, err, huge)
+	}
+	_, gotInt, err = BigIntWith(NumberOptions{Prefixes: true})(context.Background(), Cursor("0xffffffffffffffffff"))
+	if want, _ := new(big.Int).SetString("ffffffffffffffffff", 16); err != nil || gotInt.Cmp(want) != 0 {
+		t.Errorf("BigIntWith = %v, %v, want %v", gotInt, err, want)
+	}
+
+	_, gotFloat, err := BigFloatWith(NumberOptions{Precision: 200})(context.Background(), Cursor("1e400"))
+	if want, _, _ := big.ParseFloat("1e400", 10, 200, big.ToNearestEven); err != nil || gotFloat.Cmp(want) != 0 || gotFloat.Prec() != 200 {
+		t.Errorf("BigFloatWith = %v, %v, want %v", gotFloat, err, want)
+	}
+	if _, _, err := BigFloatWith(NumberOptions{Special: true})(context.Background(), Cursor("nan")); err == nil {
+		t.Errorf("BigFloatWith(%q) succeeded, want error", "nan")
+	}
+}
+
+func TestNumberRange(t *testing.T) {
+	_, _, err := Int[int8](context.Background(), Cursor("x = 300;").At(4))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) || perr.Position != 4 || perr.End != 7 {
+		t.Fatalf("Int[int8] = %v, want range error spanning 4...7", err)
+	}
+	if want := "300 out of range for int8"; perr.Message != want {
+		t.Errorf("Int[int8] message = %q, want %q", perr.Message, want)
+	}
+}
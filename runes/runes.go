@@ -1,9 +1,10 @@
 package runes
 
 import (
+	"bufio"
 	"context"
-	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/jtdubs/go-nom"
@@ -13,61 +14,76 @@ import (
 
 func Rune(want rune) nom.ParseFn[rune, rune] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-		if start.EOF() {
-			return start, rune(0), fmt.Errorf("got %q, want EOF", string(start.Read()))
-		}
-		if got := start.Read(); got != want {
-			return start, rune(0), fmt.Errorf("got %q, want %q", string(got), string(want))
+		if start.EOF() || start.Read() != want {
+			return start, rune(0), nom.Expected(start, "runes.Rune", nom.Describe(want))
 		}
 		return start.Next(), want, nil
 	})
 }
 
 func RuneNoCase(want rune) nom.ParseFn[rune, rune] {
-	return trace.Trace(fn.Satisfy(func(got rune) bool {
+	return trace.Trace(fn.Expecting(nom.Describe(want), fn.Satisfy(func(got rune) bool {
 		return strings.EqualFold(string(want), string(got))
-	}))
+	})))
 }
 
 func Tag(tag string) nom.ParseFn[rune, string] {
-	runes := make([]nom.ParseFn[rune, rune], len(tag))
-	for i, r := range tag {
-		runes[i] = Rune(r)
+	var runes []nom.ParseFn[rune, rune]
+	for _, r := range tag {
+		runes = append(runes, Rune(r))
 	}
-	return trace.Trace(Join(fn.Seq(runes...)))
+	return trace.Trace(tagged("runes.Tag", tag, Join(fn.Seq(runes...))))
 }
 
 func TagNoCase(tag string) nom.ParseFn[rune, string] {
-	runes := make([]nom.ParseFn[rune, rune], len(tag))
-	for i, r := range tag {
-		runes[i] = RuneNoCase(r)
+	var runes []nom.ParseFn[rune, rune]
+	for _, r := range tag {
+		runes = append(runes, RuneNoCase(r))
+	}
+	return trace.Trace(tagged("runes.TagNoCase", tag, Join(fn.Seq(runes...))))
+}
+
+func tagged(parser, tag string, p nom.ParseFn[rune, string]) nom.ParseFn[rune, string] {
+	return func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+		end, res, err := p(ctx, start)
+		if _, ok := nom.IsIncomplete(err); ok {
+			return start, "", nom.EOFError(start, parser, len([]rune(tag)))
+		}
+		if !nom.IsRecoverable(err) {
+			return start, "", err
+		}
+		if err != nil {
+			return start, "", &nom.ParseError{
+				Position: start.Position(),
+				Parser:   parser,
+				Expected: []string{fmt.Sprintf("%q", tag)},
+				Causes:   []error{err},
+			}
+		}
+		return end, res, nil
 	}
-	return trace.Trace(Join(fn.Seq(runes...)))
 }
 
 func OneOf(allowlist string) nom.ParseFn[rune, rune] {
+	var expected []string
+	for _, r := range allowlist {
+		expected = append(expected, nom.Describe(r))
+	}
+
 	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-		if start.EOF() {
-			return start, rune(0), errors.New("EOF")
-		}
-		got := start.Read()
-		if !strings.ContainsRune(allowlist, got) {
-			return start, rune(0), fmt.Errorf("%q not allowed", got)
+		if start.EOF() || !strings.ContainsRune(allowlist, start.Read()) {
+			return start, rune(0), nom.Expected(start, "runes.OneOf", expected...)
 		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
 func NoneOf(blocklist string) nom.ParseFn[rune, rune] {
 	return trace.Trace(func(_ context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-		if start.EOF() {
-			return start, rune(0), errors.New("EOF")
-		}
-		got := start.Read()
-		if strings.ContainsRune(blocklist, got) {
-			return start, rune(0), fmt.Errorf("%q not allowed", got)
+		if start.EOF() || strings.ContainsRune(blocklist, start.Read()) {
+			return start, rune(0), nom.Expected(start, "runes.NoneOf", fmt.Sprintf("none of %q", blocklist))
 		}
-		return start.Next(), got, nil
+		return start.Next(), start.Read(), nil
 	})
 }
 
@@ -111,108 +127,108 @@ func IsSign(r rune) bool {
 	return r == '+' || r == '-'
 }
 
+func class(what string, testFn func(rune) bool) nom.ParseFn[rune, rune] {
+	return fn.Expecting(what, fn.Satisfy(testFn))
+}
+
 func Alpha(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsAlpha))(ctx, start)
+	return trace.Trace(class("letter", IsAlpha))(ctx, start)
 }
 
 func Alpha0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsAlpha))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("letter", IsAlpha))))(ctx, start)
 }
 
 func Alpha1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsAlpha))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("letter", IsAlpha))))(ctx, start)
 }
 
 func Digit(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsDigit))(ctx, start)
+	return trace.Trace(class("digit", IsDigit))(ctx, start)
 }
 
 func Digit0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("digit", IsDigit))))(ctx, start)
 }
 
 func Digit1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("digit", IsDigit))))(ctx, start)
 }
 
 func HexDigit(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsHexDigit))(ctx, start)
+	return trace.Trace(class("hex digit", IsHexDigit))(ctx, start)
 }
 
 func HexDigit0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsHexDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("hex digit", IsHexDigit))))(ctx, start)
 }
 
 func HexDigit1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsHexDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("hex digit", IsHexDigit))))(ctx, start)
 }
 
 func OctalDigit(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsOctalDigit))(ctx, start)
+	return trace.Trace(class("octal digit", IsOctalDigit))(ctx, start)
 }
 
 func OctalDigit0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsOctalDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("octal digit", IsOctalDigit))))(ctx, start)
 }
 
 func OctalDigit1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsOctalDigit))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("octal digit", IsOctalDigit))))(ctx, start)
 }
 
 func Alphanumeric(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsAlphanumeric))(ctx, start)
+	return trace.Trace(class("alphanumeric", IsAlphanumeric))(ctx, start)
 }
 
 func Alphanumeric0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsAlphanumeric))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("alphanumeric", IsAlphanumeric))))(ctx, start)
 }
 
 func Alphanumeric1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsAlphanumeric))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("alphanumeric", IsAlphanumeric))))(ctx, start)
 }
 
 func Space(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsSpace))(ctx, start)
+	return trace.Trace(class("space", IsSpace))(ctx, start)
 }
 
 func Space0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsSpace))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("space", IsSpace))))(ctx, start)
 }
 
 func Space1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsSpace))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("space", IsSpace))))(ctx, start)
 }
 
 func Multispace(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsMultispace))(ctx, start)
+	return trace.Trace(class("whitespace", IsMultispace))(ctx, start)
 }
 
 func Multispace0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many0(fn.Satisfy(IsMultispace))))(ctx, start)
+	return trace.Trace(Join(fn.Many0(class("whitespace", IsMultispace))))(ctx, start)
 }
 
 func Multispace1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
-	return trace.Trace(Join(fn.Many1(fn.Satisfy(IsMultispace))))(ctx, start)
+	return trace.Trace(Join(fn.Many1(class("whitespace", IsMultispace))))(ctx, start)
 }
 
 func Sign(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
-	return trace.Trace(fn.Satisfy(IsSign))(ctx, start)
+	return trace.Trace(class("sign", IsSign))(ctx, start)
 }
 
 func Phrase[T any](ps ...nom.ParseFn[rune, T]) nom.ParseFn[rune, []T] {
 	var parts []nom.ParseFn[rune, T]
 	for _, p := range ps {
-		parts = append(parts, fn.Preceded(Space0, p))
+		parts = append(parts, Lexeme(p))
 	}
 	return trace.Trace(fn.Seq(parts...))
 }
 
 func Surrounded[T, U, V any](left nom.ParseFn[rune, T], right nom.ParseFn[rune, U], middle nom.ParseFn[rune, V]) nom.ParseFn[rune, V] {
-	return trace.Trace(fn.Surrounded(
-		fn.Preceded(Space0, left),
-		fn.Preceded(Space0, right),
-		fn.Preceded(Space0, middle),
-	))
+	return trace.Trace(fn.Surrounded(Lexeme(left), Lexeme(right), Lexeme(middle)))
 }
 
 func SurroundedBy[T any](left, right rune, middle nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
@@ -244,3 +260,23 @@ func Cons(p nom.ParseFn[rune, rune], ps nom.ParseFn[rune, string]) nom.ParseFn[r
 func Cursor(s string) nom.Cursor[rune] {
 	return nom.NewCursor([]rune(s))
 }
+
+func Reader(r io.Reader) nom.Cursor[rune] {
+	br := bufio.NewReader(r)
+	return nom.NewStreamCursor(func(rs []rune) (int, error) {
+		n := 0
+		for n < len(rs) {
+			r, _, err := br.ReadRune()
+			if err != nil {
+				return n, err
+			}
+			rs[n] = r
+			n++
+			// Avoid blocking for more input than the reader has already produced.
+			if br.Buffered() == 0 {
+				break
+			}
+		}
+		return n, nil
+	})
+}
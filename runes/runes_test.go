@@ -2,7 +2,9 @@ package runes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -324,3 +326,51 @@ func TestCons(t *testing.T) {
 	validate(t, "Concat(%q)", p, "abc", 1, "a", false)
 	validate(t, "Concat(%q)", p, "123", 0, "", true)
 }
+
+func TestTagError(t *testing.T) {
+	_, _, err := Tag("Hello")(context.Background(), Cursor("Help"))
+	var perr *nom.ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Tag() err = %v, want *nom.ParseError", err)
+	}
+	if want := `runes.Tag: at 0, expected "Hello"`; perr.Error() != want {
+		t.Errorf("Tag() err = %q, want %q", perr.Error(), want)
+	}
+}
+
+func TestReader(t *testing.T) {
+	word := Join(fn.Many1(NoneOf(" \n")))
+	p := fn.Many0(fn.Release(fn.Terminated(word, Multispace0)))
+	end, got, err := p(context.Background(), Reader(strings.NewReader("héllo  wörld\nfoo")))
+	if err != nil {
+		t.Fatalf("Reader() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"héllo", "wörld", "foo"}, got); diff != "" {
+		t.Errorf("Reader() result unexpected diff (-want +got):\n%v\n", diff)
+	}
+	if !end.EOF() || end.Position() != 16 {
+		t.Errorf("Reader() cursor = %v, want EOF at %v", end.Position(), 16)
+	}
+}
+
+func TestTagIncomplete(t *testing.T) {
+	p := Tag("Hello")
+	for _, tc := range []struct {
+		in       string
+		wantNeed int
+		wantErr  bool
+	}{
+		{"Hel", 2, true},
+		{"", 5, true},
+		{"Hex", 0, true},
+		{"Hello", 0, false},
+	} {
+		_, _, err := p(context.Background(), Cursor(tc.in).WithPartial(true))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("Tag(%q) err = %v, want error %v", tc.in, err, tc.wantErr)
+		}
+		if needed, _ := nom.IsIncomplete(err); needed != tc.wantNeed {
+			t.Errorf("Tag(%q) needed = %v, want %v", tc.in, needed, tc.wantNeed)
+		}
+	}
+}
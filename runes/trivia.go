@@ -0,0 +1,75 @@
+package runes
+
+import (
+	"context"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+type ContextKeyType int
+
+const (
+	TriviaKey ContextKeyType = iota
+	IndentKey
+)
+
+// WithTrivia configures the whitespace and comments skipped before tokens.
+// Each piece must consume input when it succeeds; Trivia skips any sequence
+// of them. Without trivia in the context, Trivia skips spaces and tabs.
+func WithTrivia(ctx context.Context, pieces ...nom.ParseFn[rune, string]) context.Context {
+	return context.WithValue(ctx, TriviaKey, Concat(fn.Many0(fn.Alt(pieces...))))
+}
+
+func Trivia(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+		if trivia, ok := ctx.Value(TriviaKey).(nom.ParseFn[rune, string]); ok {
+			return trivia(ctx, start)
+		}
+		return Space0(ctx, start)
+	})(ctx, start)
+}
+
+// Lexeme skips any trivia before p.
+func Lexeme[T any](p nom.ParseFn[rune, T]) nom.ParseFn[rune, T] {
+	return trace.Trace(fn.Preceded(Trivia, p))
+}
+
+func Symbol(tag string) nom.ParseFn[rune, string] {
+	return trace.Trace(Lexeme(Tag(tag)))
+}
+
+// LineComment recognizes prefix and the rest of its line, excluding the line
+// terminator.
+func LineComment(prefix string) nom.ParseFn[rune, string] {
+	return trace.Trace(Recognize(fn.Preceded(Tag(prefix), fn.Many0(NoneOf("\r\n")))))
+}
+
+// BlockComment recognizes a comment from open to close. Nested comments must
+// be balanced.
+func BlockComment(open, close string, nested bool) nom.ParseFn[rune, string] {
+	openTag, closeTag := Tag(open), Tag(close)
+	return trace.Trace(func(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+		end, _, err := openTag(ctx, start)
+		if err != nil {
+			return start, "", err
+		}
+		for depth := 1; depth > 0; {
+			if end.EOF() {
+				if end.Partial() {
+					return start, "", nom.EOFError(end, "runes.BlockComment", len([]rune(close)))
+				}
+				return start, "", literalError("runes.BlockComment", start, end, "unterminated comment, want %q", close)
+			}
+			if next, _, err := closeTag(ctx, end); err == nil {
+				depth, end = depth-1, next
+			} else if next, _, err := openTag(ctx, end); err == nil && nested {
+				depth, end = depth+1, next
+			} else {
+				end = end.Next()
+			}
+		}
+		return end, string(start.To(end)), nil
+	})
+}
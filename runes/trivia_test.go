@@ -0,0 +1,51 @@
+package runes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom/fn"
+)
+
+func TestLineComment(t *testing.T) {
+	p := LineComment("//")
+	validate(t, "LineComment(%q)", p, "// note\nx", 7, "// note", false)
+	validate(t, "LineComment(%q)", p, "//", 2, "//", false)
+	validate(t, "LineComment(%q)", p, "/ x", 0, "", true)
+}
+
+func TestBlockComment(t *testing.T) {
+	flat := BlockComment("/*", "*/", false)
+	validate(t, "BlockComment(%q)", flat, "/* a */ b", 7, "/* a */", false)
+	validate(t, "BlockComment(%q)", flat, "/* /* a */ b */", 10, "/* /* a */", false)
+	validate(t, "BlockComment(%q)", flat, "/* a", 0, "", true)
+
+	nested := BlockComment("/*", "*/", true)
+	validate(t, "BlockComment(%q)", nested, "/* /* a */ b */ c", 15, "/* /* a */ b */", false)
+	validate(t, "BlockComment(%q)", nested, "/* /* a */ b", 0, "", true)
+}
+
+func TestTrivia(t *testing.T) {
+	p := Phrase(Tag("let"), Tag("x"), Tag("="), Tag("1"))
+	in := "let // name\n  x /* is */ = # one\n 1"
+	want := []string{"let", "x", "=", "1"}
+
+	// By default only spaces and tabs are skipped.
+	validate(t, "Phrase(%q)", p, in, 0, nil, true)
+
+	ctx := WithTrivia(context.Background(), Multispace1, LineComment("//"), LineComment("#"), BlockComment("/*", "*/", true))
+	end, got, err := p(ctx, Cursor(in))
+	if err != nil || end.Position() != len(in) {
+		t.Fatalf("Phrase() with trivia = %v, %v, want %v", end, err, len(in))
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Phrase() with trivia unexpected diff (-want +got):\n%v\n", diff)
+	}
+
+	list := SurroundedBy('[', ']', fn.SeparatedList0(Symbol(","), Lexeme(Digit1)))
+	end, gotList, err := list(ctx, Cursor("[ 1, // one\n 2 /* two */ ]"))
+	if err != nil || end.Position() != 26 || !cmp.Equal(gotList, []string{"1", "2"}) {
+		t.Errorf("SurroundedBy() with trivia = %v, %v, %v", end, gotList, err)
+	}
+}
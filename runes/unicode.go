@@ -0,0 +1,193 @@
+package runes
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jtdubs/go-nom"
+	"github.com/jtdubs/go-nom/fn"
+	"github.com/jtdubs/go-nom/trace"
+)
+
+// notXIDStart and notXIDContinue are the characters that ID_Start and
+// ID_Continue include but their NFKC-closed XID counterparts do not.
+var (
+	notXIDStart = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+			{Lo: 0x0e33, Hi: 0x0eb3, Stride: 0x80},
+			{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+			{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+			{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+			{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+			{Lo: 0xff9e, Hi: 0xff9f, Stride: 1},
+		},
+	}
+	notXIDContinue = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x037a, Hi: 0x037a, Stride: 1},
+			{Lo: 0x309b, Hi: 0x309c, Stride: 1},
+			{Lo: 0xfc5e, Hi: 0xfc63, Stride: 1},
+			{Lo: 0xfdfa, Hi: 0xfdfb, Stride: 1},
+			{Lo: 0xfe70, Hi: 0xfe7e, Stride: 2},
+		},
+	}
+)
+
+func IsUnicodeAlpha(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.M)
+}
+
+func IsUnicodeAlphanumeric(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.M, unicode.Nd)
+}
+
+func IsUnicodeDigit(r rune) bool {
+	return unicode.Is(unicode.Nd, r)
+}
+
+func IsUnicodeSpace(r rune) bool {
+	return r == '\t' || unicode.Is(unicode.Zs, r)
+}
+
+func IsUnicodeMultispace(r rune) bool {
+	return unicode.IsSpace(r)
+}
+
+func isIDStart(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.Nl, unicode.Other_ID_Start) &&
+		!unicode.In(r, unicode.Pattern_Syntax, unicode.Pattern_White_Space)
+}
+
+func isIDContinue(r rune) bool {
+	return isIDStart(r) ||
+		unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Continue) &&
+			!unicode.In(r, unicode.Pattern_Syntax, unicode.Pattern_White_Space)
+}
+
+func IsXIDStart(r rune) bool {
+	return isIDStart(r) && !unicode.Is(notXIDStart, r)
+}
+
+func IsXIDContinue(r rune) bool {
+	return isIDContinue(r) && !unicode.Is(notXIDContinue, r)
+}
+
+// className names a set of tables after the unicode package's categories,
+// scripts and properties.
+func className(tables []*unicode.RangeTable) string {
+	var names []string
+	for _, table := range tables {
+		name := ""
+		for _, m := range []map[string]*unicode.RangeTable{unicode.Categories, unicode.Scripts, unicode.Properties} {
+			for n, t := range m {
+				if t == table && (name == "" || n < name) {
+					name = n
+				}
+			}
+		}
+		if name == "" {
+			name = "character class"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " or ")
+}
+
+func inClass(tables []*unicode.RangeTable) nom.ParseFn[rune, rune] {
+	return class(className(tables), func(r rune) bool { return unicode.In(r, tables...) })
+}
+
+func InClass(tables ...*unicode.RangeTable) nom.ParseFn[rune, rune] {
+	return trace.Trace(inClass(tables))
+}
+
+func InClass0(tables ...*unicode.RangeTable) nom.ParseFn[rune, string] {
+	return trace.Trace(Join(fn.Many0(inClass(tables))))
+}
+
+func InClass1(tables ...*unicode.RangeTable) nom.ParseFn[rune, string] {
+	return trace.Trace(Join(fn.Many1(inClass(tables))))
+}
+
+func UnicodeAlpha(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("letter", IsUnicodeAlpha))(ctx, start)
+}
+
+func UnicodeAlpha0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("letter", IsUnicodeAlpha))))(ctx, start)
+}
+
+func UnicodeAlpha1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("letter", IsUnicodeAlpha))))(ctx, start)
+}
+
+func UnicodeDigit(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("digit", IsUnicodeDigit))(ctx, start)
+}
+
+func UnicodeDigit0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("digit", IsUnicodeDigit))))(ctx, start)
+}
+
+func UnicodeDigit1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("digit", IsUnicodeDigit))))(ctx, start)
+}
+
+func UnicodeAlphanumeric(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("alphanumeric", IsUnicodeAlphanumeric))(ctx, start)
+}
+
+func UnicodeAlphanumeric0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("alphanumeric", IsUnicodeAlphanumeric))))(ctx, start)
+}
+
+func UnicodeAlphanumeric1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("alphanumeric", IsUnicodeAlphanumeric))))(ctx, start)
+}
+
+func UnicodeSpace(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("space", IsUnicodeSpace))(ctx, start)
+}
+
+func UnicodeSpace0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("space", IsUnicodeSpace))))(ctx, start)
+}
+
+func UnicodeSpace1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("space", IsUnicodeSpace))))(ctx, start)
+}
+
+func UnicodeMultispace(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("whitespace", IsUnicodeMultispace))(ctx, start)
+}
+
+func UnicodeMultispace0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("whitespace", IsUnicodeMultispace))))(ctx, start)
+}
+
+func UnicodeMultispace1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("whitespace", IsUnicodeMultispace))))(ctx, start)
+}
+
+func XIDStart(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("identifier", IsXIDStart))(ctx, start)
+}
+
+func XIDContinue(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], rune, error) {
+	return trace.Trace(class("identifier character", IsXIDContinue))(ctx, start)
+}
+
+func XIDContinue0(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many0(class("identifier character", IsXIDContinue))))(ctx, start)
+}
+
+func XIDContinue1(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(Join(fn.Many1(class("identifier character", IsXIDContinue))))(ctx, start)
+}
+
+// Identifier recognizes a Unicode identifier, as defined by UAX #31.
+func Identifier(ctx context.Context, start nom.Cursor[rune]) (nom.Cursor[rune], string, error) {
+	return trace.Trace(fn.Expecting("identifier", Cons(XIDStart, XIDContinue0)))(ctx, start)
+}
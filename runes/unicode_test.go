@@ -0,0 +1,69 @@
+package runes
+
+import (
+	"context"
+	"testing"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jtdubs/go-nom"
+)
+
+func TestUnicodeAlpha1(t *testing.T) {
+	p := UnicodeAlpha1
+	validate(t, "UnicodeAlpha1(%q)", p, "café au lait", 4, "café", false)
+	validate(t, "UnicodeAlpha1(%q)", p, "café!", 5, "café", false)
+	validate(t, "UnicodeAlpha1(%q)", p, "名前=1", 2, "名前", false)
+	validate(t, "UnicodeAlpha1(%q)", p, "1abc", 0, "", true)
+	validate(t, "UnicodeAlpha1(%q)", p, "", 0, "", true)
+}
+
+func TestUnicodeDigit1(t *testing.T) {
+	p := UnicodeDigit1
+	validate(t, "UnicodeDigit1(%q)", p, "١٢٣x", 3, "١٢٣", false)
+	validate(t, "UnicodeDigit1(%q)", p, "42", 2, "42", false)
+	validate(t, "UnicodeDigit1(%q)", p, "Ⅻ", 0, "", true)
+}
+
+func TestUnicodeAlphanumeric1(t *testing.T) {
+	p := UnicodeAlphanumeric1
+	validate(t, "UnicodeAlphanumeric1(%q)", p, "über42 x", 6, "über42", false)
+	validate(t, "UnicodeAlphanumeric1(%q)", p, "_", 0, "", true)
+}
+
+func TestUnicodeSpace(t *testing.T) {
+	validate(t, "UnicodeSpace1(%q)", UnicodeSpace1, " 　\t x", 4, " 　\t ", false)
+	validate(t, "UnicodeSpace1(%q)", UnicodeSpace1, "\n", 0, "", true)
+	validate(t, "UnicodeMultispace1(%q)", UnicodeMultispace1, " \n x", 3, " \n ", false)
+}
+
+func TestIdentifier(t *testing.T) {
+	p := Identifier
+	validate(t, "Identifier(%q)", p, "café = 1", 4, "café", false)
+	validate(t, "Identifier(%q)", p, "名前", 2, "名前", false)
+	validate(t, "Identifier(%q)", p, "x_1́y-z", 5, "x_1́y", false)
+	validate(t, "Identifier(%q)", p, "_x", 0, "", true)
+	validate(t, "Identifier(%q)", p, "1x", 0, "", true)
+
+	for _, r := range []rune{'ͺ', '゛', 'ﹰ'} {
+		if IsXIDStart(r) || IsXIDContinue(r) {
+			t.Errorf("IsXIDStart/IsXIDContinue(%U) = true, want false", r)
+		}
+	}
+	if IsXIDStart('ำ') || !IsXIDContinue('ำ') {
+		t.Errorf("IsXIDStart/IsXIDContinue(U+0E33) = %v, %v, want false, true", IsXIDStart('ำ'), IsXIDContinue('ำ'))
+	}
+}
+
+func TestInClass(t *testing.T) {
+	validate(t, "InClass(Greek)(%q)", InClass(unicode.Greek), "αβγ", 1, 'α', false)
+	validate(t, "InClass0(Greek)(%q)", InClass0(unicode.Greek), "abc", 0, "", false)
+	validate(t, "InClass1(Greek)(%q)", InClass1(unicode.Greek), "αβγabc", 3, "αβγ", false)
+	validate(t, "InClass1(Greek)(%q)", InClass1(unicode.Greek), "abc", 0, "", true)
+	validate(t, "InClass1(Han, Hiragana)(%q)", InClass1(unicode.Han, unicode.Hiragana), "名前です!", 4, "名前です", false)
+
+	_, _, err := InClass(unicode.Greek, unicode.Nd)(context.Background(), Cursor("x"))
+	if got, want := nom.Expectations(err), []string{"Greek or Nd"}; !cmp.Equal(got, want) {
+		t.Errorf("InClass(Greek, Nd) expected %v, want %v", got, want)
+	}
+}
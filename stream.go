@@ -0,0 +1,204 @@
+package nom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+)
+
+const streamChunkSize = 4096
+
+var ErrReleased = errors.New("nom: input has been released")
+
+// stream buffers items read on demand. Items before base have been released
+// and can no longer be read; items in [base, base+len(window)) are retained.
+// The dropped items released since the window was last copied still sit in
+// front of it in the same array.
+type stream[T comparable] struct {
+	mu      sync.Mutex
+	read    func([]T) (int, error)
+	chunk   int
+	window  []T
+	base    int
+	dropped int
+	err     error
+	lines   *lineIndex
+}
+
+func NewStreamCursor[T comparable](read func([]T) (int, error)) Cursor[T] {
+	return NewStreamCursorSize(streamChunkSize, read)
+}
+
+// NewStreamCursorSize is NewStreamCursor, but reads at least chunk items at a
+// time rather than 4096, for streams that are short or slow to produce.
+func NewStreamCursorSize[T comparable](chunk int, read func([]T) (int, error)) Cursor[T] {
+	if chunk < 1 {
+		chunk = 1
+	}
+	return Cursor[T]{
+		stream: &stream[T]{read: read, chunk: chunk, lines: newLineIndex(LineOptions{})},
+	}
+}
+
+func (s *stream[T]) available(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetch(n)
+}
+
+func (s *stream[T]) fetch(n int) bool {
+	for empty := 0; s.base+len(s.window) < n && s.err == nil; {
+		if s.fill() {
+			empty = 0
+		} else if empty++; empty >= 100 {
+			s.err = io.ErrNoProgress
+		}
+	}
+	return s.base+len(s.window) >= n
+}
+
+func (s *stream[T]) fetchMore() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetch(s.base + len(s.window) + 1)
+}
+
+func (s *stream[T]) fetchAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetch(math.MaxInt)
+	return s.base + len(s.window)
+}
+
+func (s *stream[T]) fill() bool {
+	if cap(s.window)-len(s.window) < s.chunk {
+		window := make([]T, len(s.window), 2*cap(s.window)+s.chunk)
+		copy(window, s.window)
+		s.window = window
+		s.dropped = 0
+	}
+	n, err := s.read(s.window[len(s.window):cap(s.window)])
+	if n < 0 {
+		n, err = 0, fmt.Errorf("nom: read returned %v items", n)
+	}
+	s.lines.feed(any(s.window[len(s.window) : len(s.window)+n]))
+	s.window = s.window[:len(s.window)+n]
+	if err != nil {
+		s.err = err
+		s.lines.finish()
+	}
+	return n > 0
+}
+
+// at returns the item at i, or the zero value if it has been released.
+func (s *stream[T]) at(i int) (value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i >= s.base {
+		value = s.window[i-s.base]
+	}
+	return
+}
+
+func (s *stream[T]) addr(i int) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < s.base {
+		return nil
+	}
+	return &s.window[i-s.base]
+}
+
+// slice returns the items in [from, to), or nil if any have been released.
+func (s *stream[T]) slice(from, to int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if from < s.base {
+		return nil
+	}
+	from, to = from-s.base, to-s.base
+	return s.window[from:to:to]
+}
+
+func (s *stream[T]) release(offset int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if offset <= s.base {
+		return
+	}
+	n := offset - s.base
+	if n > len(s.window) {
+		n = len(s.window)
+	}
+	s.window = s.window[n:]
+	s.base += n
+	s.dropped += n
+	// Once the released prefix outweighs the retained items, copy them so that
+	// it can be collected.
+	if s.dropped >= s.chunk && s.dropped >= len(s.window) {
+		s.window = append(make([]T, 0, len(s.window)+s.chunk), s.window...)
+		s.dropped = 0
+	}
+	s.lines.release(s.base)
+}
+
+func (s *stream[T]) retained() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.base
+}
+
+func (s *stream[T]) index() *lineIndex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lines
+}
+
+// setLineOptions rebuilds the line index with new options. Once a prefix of
+// the stream has been released it can no longer be rescanned, so the existing
+// index is kept.
+func (s *stream[T]) setLineOptions(opts LineOptions) *lineIndex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.base == 0 {
+		s.lines = newLineIndex(opts)
+		s.lines.feed(any(s.window))
+		if s.err != nil {
+			s.lines.finish()
+		}
+	}
+	return s.lines
+}
+
+func (s *stream[T]) error() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+func (c Cursor[T]) Release() {
+	if c.stream != nil {
+		c.stream.release(c.offset)
+	}
+}
+
+// Err returns the error that ended a stream, if any. Once the cursor's
+// position has been released it reads as EOF, and Err returns ErrReleased.
+func (c Cursor[T]) Err() error {
+	if c.released() {
+		return ErrReleased
+	}
+	if c.stream != nil {
+		return c.stream.error()
+	}
+	return nil
+}
+
+func (c Cursor[T]) released() bool {
+	return c.stream != nil && c.offset < c.stream.retained()
+}
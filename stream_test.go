@@ -0,0 +1,108 @@
+package nom
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestStreamCursor(t *testing.T) {
+	r := iotest.OneByteReader(bytes.NewReader([]byte("hello\nworld")))
+	start := NewStreamCursor(r.Read)
+
+	c := start
+	for i := 0; i < 7; i++ {
+		c = c.Next()
+	}
+	if got, want := c.Read(), byte('o'); got != want {
+		t.Errorf("Read() = %q, want %q", got, want)
+	}
+	if got, want := string(start.To(c)), "hello\nw"; got != want {
+		t.Errorf("To() = %q, want %q", got, want)
+	}
+	if got, want := c.Location(), (Location{2, 2}); got != want {
+		t.Errorf("Location() = %v, want %v", got, want)
+	}
+	if got, want := string(c.Line()), "world"; got != want {
+		t.Errorf("Line() = %q, want %q", got, want)
+	}
+
+	c.Release()
+	if got, want := string(c.Rest()), "orld"; got != want {
+		t.Errorf("Rest() = %q, want %q", got, want)
+	}
+	if got, want := c.Location(), (Location{2, 2}); got != want {
+		t.Errorf("Location() after Release() = %v, want %v", got, want)
+	}
+	if !c.ToEOF().EOF() || c.Len() != 4 {
+		t.Errorf("Len() = %v, want 4", c.Len())
+	}
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	if !start.EOF() || start.Read() != 0 || start.To(c) != nil {
+		t.Errorf("released cursor EOF() = %v, Read() = %q, want EOF", start.EOF(), start.Read())
+	}
+	if err := start.Err(); err != ErrReleased {
+		t.Errorf("Err() of released cursor = %v, want %v", err, ErrReleased)
+	}
+	if err := Expected(start, "test", "h"); err.Kind != Failure || !errors.Is(err, ErrReleased) {
+		t.Errorf("Expected() at released cursor = %v, want failure", err)
+	}
+}
+
+func TestStreamReleaseLines(t *testing.T) {
+	text := strings.Repeat("line\n", 1000)
+	c := NewStreamCursor(strings.NewReader(text).Read)
+	for i := 0; i < len(text); i++ {
+		c = c.Next()
+		c.Release()
+	}
+	if got := len(c.stream.lines.starts); got > 2 {
+		t.Errorf("line index retains %v lines after Release(), want at most 2", got)
+	}
+	if got, want := c.Location(), (Location{1001, 1}); got != want {
+		t.Errorf("Location() = %v, want %v", got, want)
+	}
+}
+
+func TestStreamReleaseWindow(t *testing.T) {
+	text := strings.Repeat("line\n", 20000)
+	c := NewStreamCursor(strings.NewReader(text).Read)
+	allocs := testing.AllocsPerRun(1, func() {
+		for !c.EOF() {
+			c = c.Next()
+			c.Release()
+		}
+	})
+	if allocs > 100 {
+		t.Errorf("Release() of %v items made %v allocations, want at most 100", len(text), allocs)
+	}
+	if got := c.stream.dropped + cap(c.stream.window); got > 4*streamChunkSize {
+		t.Errorf("window array holds %v items after Release(), want at most %v", got, 4*streamChunkSize)
+	}
+}
+
+func TestStreamCursorSize(t *testing.T) {
+	c := NewStreamCursorSize(8, strings.NewReader("hello, world").Read)
+	if got, want := string(c.At(3).Rest()), "lo, world"; got != want {
+		t.Errorf("Rest() = %q, want %q", got, want)
+	}
+	if got := cap(c.stream.window); got > 64 {
+		t.Errorf("window capacity = %v, want at most 64", got)
+	}
+}
+
+func TestStreamCursorError(t *testing.T) {
+	wantErr := errors.New("oops")
+	c := NewStreamCursor(iotest.ErrReader(wantErr).Read)
+	if !c.EOF() {
+		t.Errorf("EOF() = false, want true")
+	}
+	if err := c.Err(); err != wantErr {
+		t.Errorf("Err() = %v, want %v", err, wantErr)
+	}
+}
@@ -2,6 +2,7 @@ package trace
 
 import (
 	"context"
+	"reflect"
 	"runtime"
 	"strings"
 
@@ -44,6 +45,31 @@ func Hidden[C comparable, T any](fn nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	}
 }
 
+// shortName trims a function's package path and type parameters, leaving
+// names such as "fn.Many0".
+func shortName(name string) string {
+	if idx := strings.IndexRune(name, '['); idx != -1 {
+		name = name[:idx]
+	}
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+// NameOf returns the name of the function that implements a parser. Parsers
+// wrapped by Trace while tracing is supported are named after the tracer.
+func NameOf(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "unknown"
+	}
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		return shortName(f.Name())
+	}
+	return "unknown"
+}
+
 func Trace[C comparable, T any](fn nom.ParseFn[C, T]) nom.ParseFn[C, T] {
 	return TraceN(1, fn)
 }
@@ -57,13 +83,7 @@ func TraceN[C comparable, T any](depth int, fn nom.ParseFn[C, T]) nom.ParseFn[C,
 	parent := runtime.FuncForPC(pc)
 	name := "unknown"
 	if ok && parent != nil {
-		name = parent.Name()
-		if idx := strings.IndexRune(name, '['); idx != -1 {
-			name = name[:idx]
-		}
-		if idx := strings.LastIndex(name, "/"); idx != -1 {
-			name = name[idx+1:]
-		}
+		name = shortName(parent.Name())
 	}
 
 	return func(ctx context.Context, start nom.Cursor[C]) (end nom.Cursor[C], res T, err error) {
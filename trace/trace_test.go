@@ -90,3 +90,20 @@ func TestTracing(t *testing.T) {
 		}
 	}
 }
+
+func TestNameOf(t *testing.T) {
+	for _, tc := range []struct {
+		fn   any
+		want string
+	}{
+		{testParseWord, "trace.testParseWord"},
+		{nom.ParseFn[rune, []rune](testParseWord), "trace.testParseWord"},
+		{Hidden[rune, []rune], "trace.Hidden"},
+		{nil, "unknown"},
+		{42, "unknown"},
+	} {
+		if got := NameOf(tc.fn); got != tc.want {
+			t.Errorf("NameOf(%T) = %q, want %q", tc.fn, got, tc.want)
+		}
+	}
+}